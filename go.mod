@@ -1,6 +1,6 @@
 module github.com/kradalby/terraform-provider-opnsense
 
-go 1.15
+go 1.21
 
 // replace github.com/kradalby/opnsense-go => ../opnsense-go
 
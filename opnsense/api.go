@@ -0,0 +1,352 @@
+package opnsense
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kradalby/opnsense-go/opnsense"
+	uuid "github.com/satori/go.uuid"
+)
+
+// The functions and types in this file talk to the OPNsense MVC API
+// directly for the models that opnsense-go does not wrap. All MVC models
+// share the same layout: a get endpoint returning the item under a key,
+// add/set/del endpoints returning a result, and a service controller with
+// a reconfigure action.
+
+const (
+	mvcResultSaved    = "saved"
+	mvcResultDeleted  = "deleted"
+	mvcResultNotFound = "not found"
+	mvcStatusOk       = "ok"
+)
+
+type mvcResponse struct {
+	Result      string                 `json:"result"`
+	Status      string                 `json:"status"`
+	UUID        string                 `json:"uuid"`
+	Validations map[string]interface{} `json:"validations"`
+}
+
+func (r *mvcResponse) err(api string) error {
+	if len(r.Validations) == 0 {
+		return fmt.Errorf("%s returned result %q: %w", api, r.Result, ErrStatusNotOk)
+	}
+
+	fields := make([]string, 0, len(r.Validations))
+	for field := range r.Validations {
+		fields = append(fields, field)
+	}
+
+	sort.Strings(fields)
+
+	messages := make([]string, len(fields))
+	for index, field := range fields {
+		messages[index] = fmt.Sprintf("%s: %v", field, r.Validations[field])
+	}
+
+	return fmt.Errorf("%s failed validation (%s): %w", api, strings.Join(messages, ", "), ErrStatusNotOk)
+}
+
+// mvcGet fetches the item stored under key from api and decodes it into item.
+func mvcGet(c *opnsense.Client, api string, key string, item interface{}) error {
+	var raw json.RawMessage
+
+	err := c.GetAndUnmarshal(api, &raw)
+	if err != nil {
+		return err
+	}
+
+	// OPNsense answers with an empty array when the requested uuid does not exist
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("[]")) {
+		return fmt.Errorf("%s: %w", api, ErrNotFound)
+	}
+
+	response := map[string]json.RawMessage{}
+
+	err = json.Unmarshal(raw, &response)
+	if err != nil {
+		return err
+	}
+
+	data, ok := response[key]
+	if !ok {
+		return fmt.Errorf("%s did not return %q: %w", api, key, ErrNotFound)
+	}
+
+	return json.Unmarshal(data, item)
+}
+
+// mvcSearch fetches all rows from a search endpoint and decodes them into rows.
+func mvcSearch(c *opnsense.Client, api string, rows interface{}) error {
+	response := struct {
+		Rows json.RawMessage `json:"rows"`
+	}{}
+
+	err := c.GetAndUnmarshal(api, &response)
+	if err != nil {
+		return err
+	}
+
+	if len(response.Rows) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(response.Rows, rows)
+}
+
+// mvcSet stores item under key through a set endpoint.
+func mvcSet(c *opnsense.Client, api string, key string, item interface{}) error {
+	response := mvcResponse{}
+
+	err := c.PostAndMarshal(api, map[string]interface{}{key: item}, &response)
+	if err != nil {
+		return err
+	}
+
+	if response.Result != mvcResultSaved {
+		return response.err(api)
+	}
+
+	return nil
+}
+
+// mvcAdd creates item under key through an add endpoint and returns the new uuid.
+func mvcAdd(c *opnsense.Client, api string, key string, item interface{}) (uuid.UUID, error) {
+	response := mvcResponse{}
+
+	err := c.PostAndMarshal(api, map[string]interface{}{key: item}, &response)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if response.Result != mvcResultSaved {
+		return uuid.Nil, response.err(api)
+	}
+
+	id, err := uuid.FromString(response.UUID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s returned %q: %w", api, response.UUID, ErrInvalidUUID)
+	}
+
+	return id, nil
+}
+
+// mvcDelete removes an item through a del endpoint, an already removed item is not an error.
+func mvcDelete(c *opnsense.Client, api string) error {
+	response := mvcResponse{}
+
+	err := c.PostAndMarshal(api, struct{}{}, &response)
+	if err != nil {
+		return err
+	}
+
+	if response.Result != mvcResultDeleted && response.Result != mvcResultNotFound {
+		return response.err(api)
+	}
+
+	return nil
+}
+
+// mvcAction runs an action like service/reconfigure and checks the returned status.
+func mvcAction(c *opnsense.Client, api string) error {
+	response := mvcResponse{}
+
+	err := c.PostAndMarshal(api, struct{}{}, &response)
+	if err != nil {
+		return err
+	}
+
+	if !strings.EqualFold(strings.TrimSpace(response.Status), mvcStatusOk) {
+		return fmt.Errorf("%s returned status %q: %w", api, response.Status, ErrStatusNotOk)
+	}
+
+	return nil
+}
+
+// mvcBool is a boolean encoded as "1" or "0".
+type mvcBool bool
+
+func (b mvcBool) MarshalJSON() ([]byte, error) {
+	if b {
+		return []byte(`"1"`), nil
+	}
+
+	return []byte(`"0"`), nil
+}
+
+func (b *mvcBool) UnmarshalJSON(data []byte) error {
+	var value interface{}
+
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+
+	*b = mvcBool(isSelected(value))
+
+	return nil
+}
+
+// mvcInt is an integer encoded as a string, zero is sent as an empty
+// string so OPNsense falls back to the field default.
+type mvcInt int
+
+func (i mvcInt) MarshalJSON() ([]byte, error) {
+	if i == 0 {
+		return []byte(`""`), nil
+	}
+
+	return json.Marshal(strconv.Itoa(int(i)))
+}
+
+func (i *mvcInt) UnmarshalJSON(data []byte) error {
+	var value interface{}
+
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+
+	if v, ok := value.(float64); ok {
+		*i = mvcInt(v)
+
+		return nil
+	}
+
+	// Numbers are sent as strings or as select options
+	selected, err := unmarshalSelected(data)
+	if err != nil {
+		return err
+	}
+
+	if len(selected) == 0 || selected[0] == "" {
+		*i = 0
+
+		return nil
+	}
+
+	n, err := strconv.Atoi(selected[0])
+	if err != nil {
+		return err
+	}
+
+	*i = mvcInt(n)
+
+	return nil
+}
+
+// mvcNumber is an integer encoded as a string like mvcInt, but zero is sent
+// as "0" for fields where it differs from the OPNsense default.
+type mvcNumber int
+
+func (n mvcNumber) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strconv.Itoa(int(n)))
+}
+
+func (n *mvcNumber) UnmarshalJSON(data []byte) error {
+	return (*mvcInt)(n).UnmarshalJSON(data)
+}
+
+// mvcOption is a single select field. OPNsense returns all options with
+// the selected one marked, but expects only the key when saving.
+type mvcOption string
+
+func (o *mvcOption) UnmarshalJSON(data []byte) error {
+	selected, err := unmarshalSelected(data)
+	if err != nil {
+		return err
+	}
+
+	*o = mvcOption(strings.Join(selected, ","))
+
+	return nil
+}
+
+// mvcList is a multi select, comma separated list or array field.
+type mvcList []string
+
+func (l mvcList) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strings.Join(l, ","))
+}
+
+func (l *mvcList) UnmarshalJSON(data []byte) error {
+	selected, err := unmarshalSelected(data)
+	if err != nil {
+		return err
+	}
+
+	*l = selected
+
+	return nil
+}
+
+type mvcSelectOption struct {
+	Value    interface{} `json:"value"`
+	Selected interface{} `json:"selected"`
+}
+
+func unmarshalSelected(data []byte) ([]string, error) {
+	var value interface{}
+
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return []string{}, nil
+		}
+
+		return strings.Split(v, ","), nil
+	case map[string]interface{}:
+		options := map[string]mvcSelectOption{}
+
+		err := json.Unmarshal(data, &options)
+		if err != nil {
+			return nil, err
+		}
+
+		selected := []string{}
+
+		for key, option := range options {
+			if key != "" && isSelected(option.Selected) {
+				selected = append(selected, key)
+			}
+		}
+
+		sort.Strings(selected)
+
+		return selected, nil
+	case []interface{}:
+		selected := make([]string, len(v))
+
+		for index, item := range v {
+			selected[index] = fmt.Sprint(item)
+		}
+
+		return selected, nil
+	default:
+		return []string{}, nil
+	}
+}
+
+func isSelected(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case string:
+		return v == "1" || v == "true"
+	default:
+		return false
+	}
+}
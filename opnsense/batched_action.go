@@ -0,0 +1,65 @@
+package opnsense
+
+import (
+	"sync"
+	"time"
+)
+
+// batchedActionWindow is how long a batched action waits for further
+// callers, Terraform applies independent resources in parallel so their
+// changes arrive within this window.
+const batchedActionWindow = 3 * time.Second
+
+// batchedAction runs an action like service/reconfigure once for all
+// callers arriving within the window instead of once per caller.
+type batchedAction struct {
+	action func() error
+	window time.Duration
+
+	mu      sync.Mutex
+	pending *actionBatch
+}
+
+type actionBatch struct {
+	done chan struct{}
+	err  error
+}
+
+func newBatchedAction(window time.Duration, action func() error) *batchedAction {
+	return &batchedAction{
+		action: action,
+		window: window,
+	}
+}
+
+// run blocks until the batch the caller joined has run and returns its error.
+func (a *batchedAction) run() error {
+	a.mu.Lock()
+
+	batch := a.pending
+	if batch == nil {
+		batch = &actionBatch{done: make(chan struct{})}
+		a.pending = batch
+
+		go a.flush(batch)
+	}
+
+	a.mu.Unlock()
+
+	<-batch.done
+
+	return batch.err
+}
+
+func (a *batchedAction) flush(batch *actionBatch) {
+	time.Sleep(a.window)
+
+	// Callers arriving while the action runs need another run to apply their change
+	a.mu.Lock()
+	a.pending = nil
+	a.mu.Unlock()
+
+	batch.err = a.action()
+
+	close(batch.done)
+}
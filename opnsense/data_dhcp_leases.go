@@ -0,0 +1,166 @@
+package opnsense
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/kradalby/opnsense-go/opnsense"
+)
+
+type keaLease struct {
+	Address       string `json:"address"`
+	HWAddress     string `json:"hwaddr"`
+	Hostname      string `json:"hostname"`
+	Interface     string `json:"if"`
+	ValidLifetime mvcInt `json:"valid_lifetime"`
+	Expire        mvcInt `json:"expire"`
+	State         mvcInt `json:"state"`
+}
+
+// keaLeaseStates maps the Kea lease state numbers to names.
+var keaLeaseStates = map[int]string{
+	0: "active",
+	1: "declined",
+	2: "expired",
+}
+
+// keaLeaseState returns the name of a lease state, unknown states are
+// passed through as their number.
+func keaLeaseState(state mvcInt) string {
+	if name, ok := keaLeaseStates[int(state)]; ok {
+		return name
+	}
+
+	return strconv.Itoa(int(state))
+}
+
+// keaLeaseTimes returns start and end of a lease. OPNsense passes the lease
+// file of Kea through, where expire is the end of the lease in seconds since
+// the epoch and valid_lifetime its length in seconds.
+func keaLeaseTimes(lease keaLease) (time.Time, time.Time) {
+	end := time.Unix(int64(lease.Expire), 0).UTC()
+
+	return end.Add(-time.Duration(lease.ValidLifetime) * time.Second), end
+}
+
+func dataDHCPLeases() *schema.Resource {
+	return &schema.Resource{
+		Description: "Leases handed out by the Kea DHCPv4 server.",
+
+		ReadContext: dataDHCPLeasesRead,
+
+		Schema: map[string]*schema.Schema{
+			"interface": {
+				Type:        schema.TypeString,
+				Description: "Only return leases on this interface, e.g. lan",
+				Optional:    true,
+			},
+			"hostname": {
+				Type:        schema.TypeString,
+				Description: "Only return leases of this host name",
+				Optional:    true,
+			},
+			"lease": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ip_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"mac_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"hostname": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"interface": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"start": {
+							Type:        schema.TypeString,
+							Description: "Start of the lease in RFC 3339 format",
+							Computed:    true,
+						},
+						"end": {
+							Type:        schema.TypeString,
+							Description: "End of the lease in RFC 3339 format",
+							Computed:    true,
+						},
+						"state": {
+							Type:        schema.TypeString,
+							Description: "One of active, declined or expired, other Kea states as their number",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataDHCPLeasesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[TRACE] Getting OPNsense client from meta")
+
+	var diags diag.Diagnostics
+
+	c := meta.(*providerMeta).client
+
+	leases, err := keaLeaseList(c)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch DHCP leases")
+
+		return diag.FromErr(err)
+	}
+
+	iface := d.Get("interface").(string)
+	hostname := d.Get("hostname").(string)
+
+	values := []map[string]interface{}{}
+
+	for _, lease := range leases {
+		if (iface != "" && lease.Interface != iface) || (hostname != "" && lease.Hostname != hostname) {
+			continue
+		}
+
+		start, end := keaLeaseTimes(lease)
+
+		values = append(values, map[string]interface{}{
+			"ip_address":  lease.Address,
+			"mac_address": lease.HWAddress,
+			"hostname":    lease.Hostname,
+			"interface":   lease.Interface,
+			"start":       start.Format(time.RFC3339),
+			"end":         end.Format(time.RFC3339),
+			"state":       keaLeaseState(lease.State),
+		})
+	}
+
+	err = d.Set("lease", values)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("dhcp_leases")
+
+	return diags
+}
+
+func keaLeaseList(c *opnsense.Client) ([]keaLease, error) {
+	leases := []keaLease{}
+
+	err := mvcSearch(c, "kea/leases4/search", &leases)
+	if err != nil {
+		return nil, err
+	}
+
+	return leases, nil
+}
@@ -0,0 +1,46 @@
+package opnsense
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestKeaLease_decode(t *testing.T) {
+	// A row of kea/leases4/search, taken from the Kea lease file
+	data := `{
+  "address": "192.168.1.100",
+  "hwaddr": "02:00:00:12:34:56",
+  "valid_lifetime": "4000",
+  "expire": "1700004000",
+  "subnet_id": "1",
+  "hostname": "host",
+  "state": "0",
+  "if": "lan"
+}`
+
+	lease := keaLease{}
+
+	err := json.Unmarshal([]byte(data), &lease)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start, end := keaLeaseTimes(lease)
+
+	if want := time.Unix(1700000000, 0).UTC(); !start.Equal(want) {
+		t.Errorf("start = %s, want %s", start, want)
+	}
+
+	if want := time.Unix(1700004000, 0).UTC(); !end.Equal(want) {
+		t.Errorf("end = %s, want %s", end, want)
+	}
+
+	if state := keaLeaseState(lease.State); state != "active" {
+		t.Errorf("state = %q, want active", state)
+	}
+
+	if state := keaLeaseState(7); state != "7" {
+		t.Errorf("unknown state = %q, want 7", state)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/kradalby/opnsense-go/opnsense"
 	uuid "github.com/satori/go.uuid"
 )
 
@@ -45,7 +44,7 @@ func dataFirewallAlias() *schema.Resource {
 func dataFirewallAliasRead(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[TRACE] Getting OPNsense client from meta")
 
-	c := meta.(*opnsense.Client)
+	c := meta.(*providerMeta).client
 
 	wantedName := d.Get("name")
 
@@ -0,0 +1,193 @@
+package opnsense
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/kradalby/opnsense-go/opnsense"
+)
+
+type firmwareChangelog struct {
+	Series  string `json:"series"`
+	Version string `json:"version"`
+	Date    string `json:"date"`
+}
+
+type firmwareInfo struct {
+	Changelog []firmwareChangelog `json:"changelog"`
+}
+
+func dataFirmwareStatus() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataFirmwareStatusRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"check": {
+				Type:        schema.TypeBool,
+				Description: "Check the mirror for updates before reading the status",
+				Optional:    true,
+				Default:     false,
+			},
+			"product_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"product_latest": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"architecture": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"last_check": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"upgrade_major_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"needs_reboot": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"update": {
+				Type:        schema.TypeList,
+				Description: "Pending package updates",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"package": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"current_version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"new_version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"repository": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"changelog_version": {
+				Type:        schema.TypeString,
+				Description: "Version of the latest changelog entry",
+				Computed:    true,
+			},
+			"changelog_date": {
+				Type:        schema.TypeString,
+				Description: "Date of the latest changelog entry",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataFirmwareStatusRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[TRACE] Getting OPNsense client from meta")
+
+	var diags diag.Diagnostics
+
+	c := meta.(*providerMeta).client
+
+	if d.Get("check").(bool) {
+		err := firmwareCheck(ctx, c, d.Timeout(schema.TimeoutRead))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	status, err := firmwareStatusGet(c)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch firmware status")
+
+		return diag.FromErr(err)
+	}
+
+	info, err := firmwareInfoGet(c)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch firmware info")
+
+		return diag.FromErr(err)
+	}
+
+	updates := make([]map[string]interface{}, 0, len(status.UpgradePackages)+len(status.NewPackages))
+
+	for _, pkg := range status.UpgradePackages {
+		updates = append(updates, map[string]interface{}{
+			"package":         pkg.Name,
+			"current_version": pkg.CurrentVersion,
+			"new_version":     pkg.NewVersion,
+			"repository":      pkg.Repository,
+		})
+	}
+
+	for _, pkg := range status.NewPackages {
+		updates = append(updates, map[string]interface{}{
+			"package":         pkg.Name,
+			"current_version": "",
+			"new_version":     pkg.Version,
+			"repository":      pkg.Repository,
+		})
+	}
+
+	latest := firmwareChangelog{}
+
+	for _, entry := range info.Changelog {
+		if compareVersions(entry.Version, latest.Version) > 0 {
+			latest = entry
+		}
+	}
+
+	values := map[string]interface{}{
+		"product_version":       status.Product.ProductVersion,
+		"product_latest":        status.Product.ProductLatest,
+		"architecture":          status.Product.ProductArch,
+		"last_check":            status.LastCheck,
+		"status":                status.Status,
+		"upgrade_major_version": status.UpgradeMajorVersion,
+		"needs_reboot":          bool(status.NeedsReboot),
+		"update":                updates,
+		"changelog_version":     latest.Version,
+		"changelog_date":        latest.Date,
+	}
+
+	err = setResourceData(d, values)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("firmware_status")
+
+	return diags
+}
+
+func firmwareInfoGet(c *opnsense.Client) (*firmwareInfo, error) {
+	info := firmwareInfo{}
+
+	err := c.GetAndUnmarshal("core/firmware/info", &info)
+	if err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
@@ -0,0 +1,144 @@
+package opnsense
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/kradalby/opnsense-go/opnsense"
+)
+
+type interfaceInfo struct {
+	Identifier  string  `json:"identifier"`
+	Device      string  `json:"device"`
+	Description string  `json:"description"`
+	Enabled     mvcBool `json:"enabled"`
+	Status      string  `json:"status"`
+	Addr4       string  `json:"addr4"`
+	Addr6       string  `json:"addr6"`
+	MTU         mvcInt  `json:"mtu"`
+	MACAddress  string  `json:"macaddr"`
+}
+
+type interfaceInfoSearch struct {
+	Rows []interfaceInfo `json:"rows"`
+}
+
+var interfaceLookupKeys = []string{"identifier", "device", "description"}
+
+func dataInterface() *schema.Resource {
+	return &schema.Resource{
+		Description: "Looks up an assigned interface, e.g. to use its identifier in " +
+			"opnsense_firewall_filter_rule instead of hard coding opt3.",
+
+		ReadContext: dataInterfaceRead,
+		Schema: map[string]*schema.Schema{
+			"identifier": {
+				Type:         schema.TypeString,
+				Description:  "Logical name of the interface, e.g. lan or opt3",
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: interfaceLookupKeys,
+			},
+			"device": {
+				Type:         schema.TypeString,
+				Description:  "Device assigned to the interface, e.g. igb1 or vlan01",
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: interfaceLookupKeys,
+			},
+			"description": {
+				Type:         schema.TypeString,
+				Description:  "Description of the interface",
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: interfaceLookupKeys,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"ipv4_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"ipv6_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"mtu": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"mac_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataInterfaceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[TRACE] Getting OPNsense client from meta")
+
+	var diags diag.Diagnostics
+
+	c := meta.(*providerMeta).client
+
+	interfaces, err := interfaceInfoList(c)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	identifier := d.Get("identifier").(string)
+	device := d.Get("device").(string)
+	description := d.Get("description").(string)
+
+	for _, iface := range interfaces {
+		if (identifier != "" && iface.Identifier != identifier) ||
+			(device != "" && iface.Device != device) ||
+			(description != "" && iface.Description != description) {
+			continue
+		}
+
+		values := map[string]interface{}{
+			"identifier":   iface.Identifier,
+			"device":       iface.Device,
+			"description":  iface.Description,
+			"enabled":      bool(iface.Enabled),
+			"status":       iface.Status,
+			"ipv4_address": iface.Addr4,
+			"ipv6_address": iface.Addr6,
+			"mtu":          int(iface.MTU),
+			"mac_address":  iface.MACAddress,
+		}
+
+		err = setResourceData(d, values)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		d.SetId(iface.Identifier)
+
+		return diags
+	}
+
+	return diag.FromErr(fmt.Errorf("interface %s%s%s: %w", identifier, device, description, ErrNotFound))
+}
+
+func interfaceInfoList(c *opnsense.Client) ([]interfaceInfo, error) {
+	search := interfaceInfoSearch{}
+
+	err := c.GetAndUnmarshal("interfaces/overview/interfacesInfo", &search)
+	if err != nil {
+		return nil, err
+	}
+
+	return search.Rows, nil
+}
@@ -0,0 +1,210 @@
+package opnsense
+
+import (
+	"context"
+	"log"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/kradalby/opnsense-go/opnsense"
+)
+
+type ipsecPhase1 struct {
+	Name        string  `json:"name"`
+	Description string  `json:"phase1desc"`
+	Connected   mvcBool `json:"connected"`
+	State       string  `json:"state"`
+	LocalID     string  `json:"local-id"`
+	RemoteID    string  `json:"remote-id"`
+	RemoteAddrs mvcList `json:"remote-addrs"`
+	InstallTime mvcInt  `json:"install-time"`
+	BytesIn     mvcInt  `json:"bytes-in"`
+	BytesOut    mvcInt  `json:"bytes-out"`
+}
+
+type ipsecPhase2 struct {
+	Name        string  `json:"name"`
+	State       string  `json:"state"`
+	InstallTime mvcInt  `json:"install-time"`
+	BytesIn     mvcInt  `json:"bytes-in"`
+	BytesOut    mvcInt  `json:"bytes-out"`
+	LocalTS     mvcList `json:"local-ts"`
+	RemoteTS    mvcList `json:"remote-ts"`
+}
+
+func dataIPsecStatus() *schema.Resource {
+	return &schema.Resource{
+		Description: "IKE and child SAs of all IPsec connections, e.g. to check after apply that tunnels are up.",
+
+		ReadContext: dataIPsecStatusRead,
+
+		Schema: map[string]*schema.Schema{
+			"ike_sa": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"connected": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"local_identity": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"remote_identity": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"remote_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"established": {
+							Type:        schema.TypeInt,
+							Description: "Seconds since the SA was established",
+							Computed:    true,
+						},
+						"bytes_in": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"bytes_out": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"child_sa": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"state": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"established": {
+										Type:        schema.TypeInt,
+										Description: "Seconds since the SA was installed",
+										Computed:    true,
+									},
+									"bytes_in": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"bytes_out": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"local_traffic_selectors": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+									"remote_traffic_selectors": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataIPsecStatusRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[TRACE] Getting OPNsense client from meta")
+
+	var diags diag.Diagnostics
+
+	c := meta.(*providerMeta).client
+
+	phase1s := []ipsecPhase1{}
+
+	err := mvcSearch(c, "ipsec/sessions/searchPhase1", &phase1s)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch IKE SAs")
+
+		return diag.FromErr(err)
+	}
+
+	ikeSAs := make([]map[string]interface{}, len(phase1s))
+
+	for index, phase1 := range phase1s {
+		childSAs, err := ipsecChildSAs(c, phase1.Name)
+		if err != nil {
+			log.Printf("[ERROR] Failed to fetch child SAs of %s", phase1.Name)
+
+			return diag.FromErr(err)
+		}
+
+		ikeSAs[index] = map[string]interface{}{
+			"name":            phase1.Name,
+			"description":     phase1.Description,
+			"connected":       bool(phase1.Connected),
+			"state":           phase1.State,
+			"local_identity":  phase1.LocalID,
+			"remote_identity": phase1.RemoteID,
+			"remote_address":  strings.Join(phase1.RemoteAddrs, ","),
+			"established":     int(phase1.InstallTime),
+			"bytes_in":        int(phase1.BytesIn),
+			"bytes_out":       int(phase1.BytesOut),
+			"child_sa":        childSAs,
+		}
+	}
+
+	err = d.Set("ike_sa", ikeSAs)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("ipsec_status")
+
+	return diags
+}
+
+func ipsecChildSAs(c *opnsense.Client, name string) ([]map[string]interface{}, error) {
+	phase2s := []ipsecPhase2{}
+
+	err := mvcSearch(c, "ipsec/sessions/searchPhase2?"+url.Values{"id": {name}}.Encode(), &phase2s)
+	if err != nil {
+		return nil, err
+	}
+
+	childSAs := make([]map[string]interface{}, len(phase2s))
+
+	for index, phase2 := range phase2s {
+		childSAs[index] = map[string]interface{}{
+			"name":                     phase2.Name,
+			"state":                    phase2.State,
+			"established":              int(phase2.InstallTime),
+			"bytes_in":                 int(phase2.BytesIn),
+			"bytes_out":                int(phase2.BytesOut),
+			"local_traffic_selectors":  []string(phase2.LocalTS),
+			"remote_traffic_selectors": []string(phase2.RemoteTS),
+		}
+	}
+
+	return childSAs, nil
+}
@@ -0,0 +1,184 @@
+package opnsense
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type openVPNExportOptions struct {
+	Template         string  `json:"template"`
+	Hostname         string  `json:"hostname"`
+	LocalPort        mvcInt  `json:"local_port"`
+	RandomLocalPort  mvcBool `json:"random_local_port"`
+	ValidateServerCN mvcBool `json:"validate_server_cn"`
+	AuthNoCache      mvcBool `json:"auth_nocache"`
+	P12Password      string  `json:"p12_password"`
+	PlainConfig      string  `json:"plain_config"`
+}
+
+// openVPNExportTextTemplates are the templates exporting a text file, the
+// others export a zip archive.
+var openVPNExportTextTemplates = map[string]bool{
+	"PlainOpenVPN": true,
+	"TheGreenBow":  true,
+}
+
+type openVPNExport struct {
+	Filename string `json:"filename"`
+	Filetype string `json:"filetype"`
+	Content  string `json:"content"`
+}
+
+func dataOpenVPNClientExport() *schema.Resource {
+	return &schema.Resource{
+		Description: "Generates the client configuration of an OpenVPN server instance for a certificate.",
+
+		ReadContext: dataOpenVPNClientExportRead,
+
+		Schema: map[string]*schema.Schema{
+			"vpn_id": {
+				Type:        schema.TypeString,
+				Description: "vpn_id of the opnsense_openvpn_instance",
+				Required:    true,
+			},
+			"certificate": {
+				Type:        schema.TypeString,
+				Description: "Reference ID of the client certificate",
+				Required:    true,
+			},
+			"template": {
+				Type: schema.TypeString,
+				Description: "Export template, PlainOpenVPN returns one file with inline certificates, " +
+					"ArchiveOpenVPN a zip with separate certificate files",
+				Optional: true,
+				Default:  "PlainOpenVPN",
+				ValidateFunc: validation.StringInSlice([]string{
+					"PlainOpenVPN", "ArchiveOpenVPN", "ViscosityVisz", "TheGreenBow",
+				}, false),
+			},
+			"hostname": {
+				Type:        schema.TypeString,
+				Description: "Host name clients connect to, empty uses the address of the instance",
+				Optional:    true,
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Description:  "Port clients connect to, empty uses the port of the instance",
+				Optional:     true,
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"random_local_port": {
+				Type:        schema.TypeBool,
+				Description: "Let the client use a random local port",
+				Optional:    true,
+				Default:     true,
+			},
+			"validate_server_cn": {
+				Type:        schema.TypeBool,
+				Description: "Verify the common name of the server certificate",
+				Optional:    true,
+				Default:     true,
+			},
+			"auth_nocache": {
+				Type:        schema.TypeBool,
+				Description: "Do not cache the password of the user in the client",
+				Optional:    true,
+				Default:     false,
+			},
+			"p12_password": {
+				Type:        schema.TypeString,
+				Description: "Password protecting the exported private key",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"custom_config": {
+				Type:        schema.TypeString,
+				Description: "Additional lines appended to the configuration",
+				Optional:    true,
+			},
+			"filename": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"content": {
+				Type:        schema.TypeString,
+				Description: "Contents of the exported file, empty for the archive templates",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"content_base64": {
+				Type:        schema.TypeString,
+				Description: "Contents of the exported file encoded as base64, e.g. for the archive templates",
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func dataOpenVPNClientExportRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[TRACE] Getting OPNsense client from meta")
+
+	var diags diag.Diagnostics
+
+	c := meta.(*providerMeta).client
+
+	vpnID := d.Get("vpn_id").(string)
+	certificate := d.Get("certificate").(string)
+
+	options := openVPNExportOptions{
+		Template:         d.Get("template").(string),
+		Hostname:         d.Get("hostname").(string),
+		LocalPort:        mvcInt(d.Get("port").(int)),
+		RandomLocalPort:  mvcBool(d.Get("random_local_port").(bool)),
+		ValidateServerCN: mvcBool(d.Get("validate_server_cn").(bool)),
+		AuthNoCache:      mvcBool(d.Get("auth_nocache").(bool)),
+		P12Password:      d.Get("p12_password").(string),
+		PlainConfig:      d.Get("custom_config").(string),
+	}
+
+	api := fmt.Sprintf("openvpn/export/download/%s/%s", vpnID, certificate)
+	export := openVPNExport{}
+
+	err := c.PostAndMarshal(api, map[string]interface{}{"openvpn_export": options}, &export)
+	if err != nil {
+		log.Printf("[ERROR] Failed to export OpenVPN client configuration")
+
+		return diag.FromErr(err)
+	}
+
+	if export.Content == "" {
+		return diag.FromErr(fmt.Errorf("%s returned no content: %w", api, ErrNotFound))
+	}
+
+	content, err := base64.StdEncoding.DecodeString(export.Content)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	values := map[string]interface{}{
+		"filename":       export.Filename,
+		"content":        "",
+		"content_base64": export.Content,
+	}
+
+	// Archives are not valid UTF-8 and only exported as base64
+	if openVPNExportTextTemplates[options.Template] {
+		values["content"] = string(content)
+	}
+
+	err = setResourceData(d, values)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", vpnID, certificate))
+
+	return diags
+}
@@ -0,0 +1,58 @@
+package opnsense
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testOpenVPNClientExportData(name string) string {
+	return testTrustCertResource(name, 0) + fmt.Sprintf(`
+data "opnsense_openvpn_client_export" "%s_archive" {
+  vpn_id      = opnsense_openvpn_instance.%s.vpn_id
+  certificate = opnsense_trust_cert.%s_user.refid
+  template    = "ArchiveOpenVPN"
+}
+`, name, name, name)
+}
+
+func TestOpenVPNClientExport_templates(t *testing.T) {
+	rName := fmt.Sprintf("a%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccTrustCertResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testOpenVPNClientExportData(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(
+						fmt.Sprintf("data.opnsense_openvpn_client_export.%s", rName),
+						"content",
+						regexp.MustCompile("(?s)remote vpn.example.com 1195.*<ca>"),
+					),
+					resource.TestMatchResourceAttr(
+						fmt.Sprintf("data.opnsense_openvpn_client_export.%s_archive", rName),
+						"filename",
+						regexp.MustCompile(`\.zip$`),
+					),
+					resource.TestCheckResourceAttr(
+						fmt.Sprintf("data.opnsense_openvpn_client_export.%s_archive", rName),
+						"content",
+						"",
+					),
+					// Zip archives start with PK\x03\x04
+					resource.TestMatchResourceAttr(
+						fmt.Sprintf("data.opnsense_openvpn_client_export.%s_archive", rName),
+						"content_base64",
+						regexp.MustCompile("^UEsDB"),
+					),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,146 @@
+package opnsense
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/kradalby/opnsense-go/opnsense"
+)
+
+type gatewayStatus struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	Status  string `json:"status"`
+	Loss    string `json:"loss"`
+	StdDev  string `json:"stddev"`
+	Delay   string `json:"delay"`
+	Monitor string `json:"monitor"`
+}
+
+type gatewayStatusList struct {
+	Items []gatewayStatus `json:"items"`
+}
+
+func dataRoutingGatewayStatus() *schema.Resource {
+	return &schema.Resource{
+		Description: "Monitoring status of all gateways, e.g. to check in a precondition " +
+			"that the gateway of an opnsense_firewall_filter_rule is online.",
+
+		ReadContext: dataRoutingGatewayStatusRead,
+
+		Schema: map[string]*schema.Schema{
+			"gateway": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Description: "One of online, down, loss, delay, delay+loss or force_down",
+							Computed:    true,
+						},
+						"rtt": {
+							Type:        schema.TypeFloat,
+							Description: "Round trip time in milliseconds",
+							Computed:    true,
+						},
+						"rttd": {
+							Type:        schema.TypeFloat,
+							Description: "Standard deviation of the round trip time in milliseconds",
+							Computed:    true,
+						},
+						"loss": {
+							Type:        schema.TypeFloat,
+							Description: "Packet loss in percent",
+							Computed:    true,
+						},
+						"monitor_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataRoutingGatewayStatusRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[TRACE] Getting OPNsense client from meta")
+
+	var diags diag.Diagnostics
+
+	c := meta.(*providerMeta).client
+
+	statuses, err := gatewayStatusGet(c)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch gateway status")
+
+		return diag.FromErr(err)
+	}
+
+	gateways := make([]map[string]interface{}, len(statuses))
+
+	for index, status := range statuses {
+		// dpinger reports a healthy gateway as none
+		state := status.Status
+		if state == "none" {
+			state = "online"
+		}
+
+		gateways[index] = map[string]interface{}{
+			"name":            status.Name,
+			"address":         status.Address,
+			"status":          state,
+			"rtt":             parseGatewayMetric(status.Delay),
+			"rttd":            parseGatewayMetric(status.StdDev),
+			"loss":            parseGatewayMetric(status.Loss),
+			"monitor_address": status.Monitor,
+		}
+	}
+
+	err = d.Set("gateway", gateways)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("gateway_status")
+
+	return diags
+}
+
+func gatewayStatusGet(c *opnsense.Client) ([]gatewayStatus, error) {
+	list := gatewayStatusList{}
+
+	err := c.GetAndUnmarshal("routes/gateway/status", &list)
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Items, nil
+}
+
+// parseGatewayMetric parses values like "1.2 ms" or "0.0 %", unmonitored
+// gateways report "~" which is returned as zero.
+func parseGatewayMetric(value string) float64 {
+	value = strings.TrimSpace(strings.TrimRight(value, "ms% "))
+
+	metric, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0
+	}
+
+	return metric
+}
@@ -0,0 +1,89 @@
+package opnsense
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataTrustCRL() *schema.Resource {
+	return &schema.Resource{
+		Description: "The current revocation list of a CA.",
+
+		ReadContext: dataTrustCRLRead,
+
+		Schema: map[string]*schema.Schema{
+			"ca": {
+				Type:        schema.TypeString,
+				Description: "refid of the CA",
+				Required:    true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"pem": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"serial": {
+				Type:        schema.TypeString,
+				Description: "CRL number of the revocation list",
+				Computed:    true,
+			},
+			"this_update": {
+				Type:        schema.TypeString,
+				Description: "Issue date of the revocation list in RFC 3339 format",
+				Computed:    true,
+			},
+			"next_update": {
+				Type:        schema.TypeString,
+				Description: "Next update of the revocation list in RFC 3339 format",
+				Computed:    true,
+			},
+			"revoked_serials": {
+				Type:        schema.TypeList,
+				Description: "Serials of the revoked certificates",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataTrustCRLRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[TRACE] Getting OPNsense client from meta")
+
+	var diags diag.Diagnostics
+
+	c := meta.(*providerMeta).client
+
+	ca := d.Get("ca").(string)
+
+	crl, err := trustCRLGet(c, ca)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch CRL of %s", ca)
+
+		return diag.FromErr(err)
+	}
+
+	values := map[string]interface{}{
+		"description":     crl.Description,
+		"pem":             crl.PEM,
+		"serial":          crl.Serial,
+		"this_update":     crl.ThisUpdate,
+		"next_update":     crl.NextUpdate,
+		"revoked_serials": crl.RevokedSerials,
+	}
+
+	err = setResourceData(d, values)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(ca)
+
+	return diags
+}
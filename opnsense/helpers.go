@@ -2,13 +2,51 @@ package opnsense
 
 import (
 	"errors"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 var (
-	ErrExpectedString          = errors.New("expected string")
-	ErrInvalidUUID             = errors.New("invalid UUID")
-	ErrMoreThanOneUUIDReturned = errors.New("more than one uuid returned")
-	ErrStatusNotOk             = errors.New("api status message not ok")
+	ErrExpectedString             = errors.New("expected string")
+	ErrFirmwareTargetNotAvailable = errors.New("firmware target version not available")
+	ErrInvalidUUID                = errors.New("invalid UUID")
+	ErrMoreThanOneUUIDReturned    = errors.New("more than one uuid returned")
+	ErrNotFound                   = errors.New("not found")
+	ErrStatusNotOk                = errors.New("api status message not ok")
 )
 
 const apiInternalErrorMsg = "Internal Error status code received"
+
+// setResourceData sets every key of values on d.
+func setResourceData(d *schema.ResourceData, values map[string]interface{}) error {
+	for k, v := range values {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// expandStringSet converts a set of strings from the schema to a slice.
+func expandStringSet(set *schema.Set) []string {
+	list := set.List()
+	strs := make([]string, len(list))
+
+	for index := range list {
+		strs[index] = list[index].(string)
+	}
+
+	return strs
+}
+
+// expandStringList converts a list of strings from the schema to a slice.
+func expandStringList(list []interface{}) []string {
+	strs := make([]string, len(list))
+
+	for index := range list {
+		strs[index] = list[index].(string)
+	}
+
+	return strs
+}
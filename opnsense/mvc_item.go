@@ -0,0 +1,180 @@
+package opnsense
+
+import (
+	"context"
+	"errors"
+	"log"
+	"path"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	uuid "github.com/satori/go.uuid"
+)
+
+// mvcItem describes an item stored by uuid in an OPNsense MVC model, e.g. a
+// VLAN, and provides the CRUD functions for the resource managing it.
+type mvcItem struct {
+	// controller serves the item endpoints, e.g. interfaces/vlan_settings
+	controller string
+	// name completes the get, add, set and del endpoints, e.g. Item, empty
+	// when the controller only serves one kind of item
+	name string
+	// key wraps the item in requests and responses, e.g. vlan
+	key string
+	// reconfigure applies the configuration after a change, may be nil
+	reconfigure func(meta *providerMeta) error
+
+	newItem func() interface{}
+	expand  func(d *schema.ResourceData) (interface{}, error)
+	flatten func(item interface{}) map[string]interface{}
+}
+
+func (i *mvcItem) endpoint(action string, id ...string) string {
+	return path.Join(append([]string{i.controller, action + i.name}, id...)...)
+}
+
+// get fetches the item with the given uuid.
+func (i *mvcItem) get(meta *providerMeta, id uuid.UUID) (interface{}, error) {
+	item := i.newItem()
+
+	err := mvcGet(meta.client, i.endpoint("get", id.String()), i.key, item)
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// search fetches the rows of all items, option fields contain display values.
+func (i *mvcItem) search(meta *providerMeta, rows interface{}) error {
+	return mvcSearch(meta.client, i.endpoint("search"), rows)
+}
+
+func (i *mvcItem) read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[TRACE] Getting OPNsense client from meta")
+
+	var diags diag.Diagnostics
+
+	log.Printf("[TRACE] Converting ID to UUID")
+
+	id, err := uuid.FromString(d.Id())
+	if err != nil {
+		log.Printf("[ERROR] Failed to parse ID")
+
+		return diag.FromErr(err)
+	}
+
+	item, err := i.get(meta.(*providerMeta), id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			d.SetId("")
+
+			return diags
+		}
+
+		log.Printf("[ERROR] Failed to fetch %s %s", i.key, id)
+
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] Configuration from OPNsense: \n")
+	log.Printf("[DEBUG] %#v \n", item)
+
+	err = setResourceData(d, i.flatten(item))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func (i *mvcItem) create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMeta)
+
+	item, err := i.expand(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	id, err := mvcAdd(m.client, i.endpoint("add"), i.key, item)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id.String())
+
+	err = i.apply(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return i.read(ctx, d, meta)
+}
+
+func (i *mvcItem) update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMeta)
+
+	id, err := uuid.FromString(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	item, err := i.expand(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = mvcSet(m.client, i.endpoint("set", id.String()), i.key, item)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = i.apply(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return i.read(ctx, d, meta)
+}
+
+func (i *mvcItem) delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMeta)
+
+	var diags diag.Diagnostics
+
+	id, err := uuid.FromString(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = mvcDelete(m.client, i.endpoint("del", id.String()))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = i.apply(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+func (i *mvcItem) apply(meta *providerMeta) error {
+	if i.reconfigure == nil {
+		return nil
+	}
+
+	return i.reconfigure(meta)
+}
+
+// mvcReconfigure returns a reconfigure function running the given action.
+func mvcReconfigure(api string) func(meta *providerMeta) error {
+	return func(meta *providerMeta) error {
+		log.Printf("[TRACE] Running %s", api)
+
+		return mvcAction(meta.client, api)
+	}
+}
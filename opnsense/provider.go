@@ -38,25 +38,83 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("OPNSENSE_ALLOW_UNVERIFIED_TLS", false),
 				Description: "Allow connection to a OPNsense server without verified TLS",
 			},
+			"wireguard_reconfigure": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("OPNSENSE_WIREGUARD_RECONFIGURE", true),
+				Description: "Reconfigure the WireGuard service after client, server or general changes",
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"opnsense_wireguard_client":     resourceWireGuardClient(),
-			"opnsense_wireguard_server":     resourceWireGuardServer(),
-			"opnsense_firewall_filter_rule": resourceFirewallFilterRule(),
-			"opnsense_firewall_alias":       resourceFirewallAlias(),
-			"opnsense_firewall_alias_util":  resourceFirewallAliasUtil(),
-			"opnsense_firmware":             resourceFirmware(),
+			"opnsense_wireguard_client":        resourceWireGuardClient(),
+			"opnsense_wireguard_server":        resourceWireGuardServer(),
+			"opnsense_wireguard_general":       resourceWireGuardGeneral(),
+			"opnsense_firewall_filter_rule":    resourceFirewallFilterRule(),
+			"opnsense_firewall_alias":          resourceFirewallAlias(),
+			"opnsense_firewall_alias_util":     resourceFirewallAliasUtil(),
+			"opnsense_firmware":                resourceFirmware(),
+			"opnsense_firmware_upgrade":        resourceFirmwareUpgrade(),
+			"opnsense_firmware_config":         resourceFirmwareConfig(),
+			"opnsense_firmware_plugin":         resourceFirmwarePlugin(),
+			"opnsense_interface_vlan":          resourceInterfaceVLAN(),
+			"opnsense_interface_lagg":          resourceInterfaceLAGG(),
+			"opnsense_interface_bridge":        resourceInterfaceBridge(),
+			"opnsense_interface_gre":           resourceInterfaceGRE(),
+			"opnsense_interface_gif":           resourceInterfaceGIF(),
+			"opnsense_interface_vxlan":         resourceInterfaceVXLAN(),
+			"opnsense_interface_vip":           resourceInterfaceVIP(),
+			"opnsense_routing_gateway":         resourceRoutingGateway(),
+			"opnsense_route":                   resourceRoute(),
+			"opnsense_unbound_host_override":   resourceUnboundHostOverride(),
+			"opnsense_unbound_domain_override": resourceUnboundDomainOverride(),
+			"opnsense_unbound_forward":         resourceUnboundForward(),
+			"opnsense_unbound_acl":             resourceUnboundACL(),
+			"opnsense_unbound_dnsbl":           resourceUnboundDNSBL(),
+			"opnsense_dhcp_server":             resourceDHCPServer(),
+			"opnsense_dhcp_static_mapping":     resourceDHCPStaticMapping(),
+			"opnsense_ipsec_connection":        resourceIPsecConnection(),
+			"opnsense_ipsec_local_auth":        resourceIPsecLocalAuth(),
+			"opnsense_ipsec_remote_auth":       resourceIPsecRemoteAuth(),
+			"opnsense_ipsec_child":             resourceIPsecChild(),
+			"opnsense_ipsec_psk":               resourceIPsecPSK(),
+			"opnsense_openvpn_instance":        resourceOpenVPNInstance(),
+			"opnsense_openvpn_client_override": resourceOpenVPNClientOverride(),
+			"opnsense_trust_ca":                resourceTrustCA(),
+			"opnsense_trust_cert":              resourceTrustCert(),
+			"opnsense_trust_crl":               resourceTrustCRL(),
+			"opnsense_system_user":             resourceSystemUser(),
+			"opnsense_system_group":            resourceSystemGroup(),
+			"opnsense_system_user_apikey":      resourceSystemUserAPIKey(),
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"opnsense_firewall_alias": dataFirewallAlias(),
+			"opnsense_firewall_alias":         dataFirewallAlias(),
+			"opnsense_firmware_status":        dataFirmwareStatus(),
+			"opnsense_interface":              dataInterface(),
+			"opnsense_routing_gateway_status": dataRoutingGatewayStatus(),
+			"opnsense_dhcp_leases":            dataDHCPLeases(),
+			"opnsense_ipsec_status":           dataIPsecStatus(),
+			"opnsense_openvpn_client_export":  dataOpenVPNClientExport(),
+			"opnsense_trust_crl":              dataTrustCRL(),
 		},
 
 		ConfigureContextFunc: providerConfigure,
 	}
 }
 
+// providerMeta is passed to all resources and data sources as meta.
+type providerMeta struct {
+	client *opnsense.Client
+
+	wireGuardReconfigure bool
+
+	unboundReconfigure *batchedAction
+
+	// privileges holds the ACL names of the OPNsense, nil when they could not be fetched
+	privileges map[string]bool
+}
+
 func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -65,6 +123,7 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 	key := d.Get("key").(string)
 	secret := d.Get("secret").(string)
 	skipTLS := d.Get("allow_unverified_tls").(bool)
+	wireGuardReconfigure := d.Get("wireguard_reconfigure").(bool)
 
 	log.Printf("[TRACE] Creating OPNsense client\n")
 
@@ -93,5 +152,18 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		return nil, diag.FromErr(err)
 	}
 
-	return c, diags
+	meta := &providerMeta{
+		client:               c,
+		wireGuardReconfigure: wireGuardReconfigure,
+		unboundReconfigure: newBatchedAction(batchedActionWindow, func() error {
+			return mvcAction(c, "unbound/service/reconfigure")
+		}),
+	}
+
+	meta.privileges, err = privilegeList(c)
+	if err != nil {
+		log.Printf("[WARN] Could not fetch privileges, they are not validated: %#v\n", err)
+	}
+
+	return meta, diags
 }
@@ -1,10 +1,13 @@
 package opnsense
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	uuid "github.com/satori/go.uuid"
 )
 
 var (
@@ -32,3 +35,23 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal("OPNSENSE_SECRET must be set for acceptance tests")
 	}
 }
+
+func testAccCheckMvcItemDestroyed(item *mvcItem, id string) error {
+	meta := testAccProvider.Meta().(*providerMeta)
+
+	itemUUID, err := uuid.FromString(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = item.get(meta, itemUUID)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return fmt.Errorf("%s %s still exists", item.key, id)
+}
@@ -0,0 +1,487 @@
+package opnsense
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type keaOptionData struct {
+	DomainNameServers mvcList `json:"domain_name_servers"`
+	Routers           mvcList `json:"routers"`
+	DomainName        string  `json:"domain_name"`
+}
+
+type keaSubnet struct {
+	Subnet                string        `json:"subnet"`
+	OptionDataAutocollect mvcBool       `json:"option_data_autocollect"`
+	OptionData            keaOptionData `json:"option_data"`
+	Pools                 string        `json:"pools"`
+	Description           string        `json:"description"`
+}
+
+type keaSubnetRow struct {
+	UUID   string `json:"uuid"`
+	Subnet string `json:"subnet"`
+}
+
+type keaGeneral struct {
+	Enabled    mvcBool `json:"enabled"`
+	Interfaces mvcList `json:"interfaces"`
+}
+
+type keaDHCPv4 struct {
+	General keaGeneral `json:"general"`
+}
+
+// keaGeneralMutex serializes changes to the Kea interface list, servers
+// for different interfaces are created in parallel.
+var keaGeneralMutex sync.Mutex
+
+var keaSubnetItem = &mvcItem{
+	controller:  "kea/dhcpv4",
+	name:        "Subnet",
+	key:         "subnet4",
+	reconfigure: mvcReconfigure("kea/service/reconfigure"),
+
+	newItem: func() interface{} { return &keaSubnet{} },
+	expand: func(d *schema.ResourceData) (interface{}, error) {
+		dnsServers := expandStringList(d.Get("dns_servers").([]interface{}))
+		gateway := d.Get("gateway").(string)
+
+		subnet := &keaSubnet{
+			Subnet: d.Get("subnet").(string),
+			// Kea derives DNS and router from the interface unless given
+			OptionDataAutocollect: mvcBool(len(dnsServers) == 0 && gateway == ""),
+			OptionData: keaOptionData{
+				DomainNameServers: dnsServers,
+				DomainName:        d.Get("domain").(string),
+			},
+			Pools:       fmt.Sprintf("%s-%s", d.Get("range_from").(string), d.Get("range_to").(string)),
+			Description: d.Get("description").(string),
+		}
+
+		if gateway != "" {
+			subnet.OptionData.Routers = mvcList{gateway}
+		}
+
+		return subnet, nil
+	},
+	flatten: func(item interface{}) map[string]interface{} {
+		subnet := item.(*keaSubnet)
+
+		// Only the first pool is managed, pools are separated by newlines
+		pool := strings.SplitN(strings.TrimSpace(subnet.Pools), "\n", 2)[0]
+		rangeFrom, rangeTo := pool, ""
+
+		if index := strings.Index(pool, "-"); index >= 0 {
+			rangeFrom, rangeTo = strings.TrimSpace(pool[:index]), strings.TrimSpace(pool[index+1:])
+		}
+
+		values := map[string]interface{}{
+			"subnet":      subnet.Subnet,
+			"range_from":  rangeFrom,
+			"range_to":    rangeTo,
+			"dns_servers": []string{},
+			"gateway":     "",
+			"domain":      subnet.OptionData.DomainName,
+			"description": subnet.Description,
+		}
+
+		if !subnet.OptionDataAutocollect {
+			values["dns_servers"] = []string(subnet.OptionData.DomainNameServers)
+
+			if len(subnet.OptionData.Routers) > 0 {
+				values["gateway"] = subnet.OptionData.Routers[0]
+			}
+		}
+
+		return values
+	},
+}
+
+func resourceDHCPServer() *schema.Resource {
+	return &schema.Resource{
+		Description: "A Kea DHCPv4 subnet served on an interface. The legacy ISC DHCP server has no API " +
+			"and can not be managed, lease times and DHCPv6 are not supported, see the README.",
+
+		CreateContext: resourceDHCPServerCreate,
+		ReadContext:   resourceDHCPServerRead,
+		UpdateContext: resourceDHCPServerUpdate,
+		DeleteContext: resourceDHCPServerDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		CustomizeDiff: resourceDHCPServerCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"interface": {
+				Type:        schema.TypeString,
+				Description: "Interface Kea listens on for the subnet, e.g. lan, its address must be inside the subnet",
+				Required:    true,
+			},
+			"subnet": {
+				Type:         schema.TypeString,
+				Description:  "Subnet of the interface, e.g. 192.168.1.0/24",
+				Required:     true,
+				ValidateFunc: validation.IsCIDRNetwork(0, 32),
+			},
+			"range_from": {
+				Type:         schema.TypeString,
+				Description:  "First address of the dynamic range",
+				Required:     true,
+				ValidateFunc: validation.IsIPv4Address,
+			},
+			"range_to": {
+				Type:         schema.TypeString,
+				Description:  "Last address of the dynamic range",
+				Required:     true,
+				ValidateFunc: validation.IsIPv4Address,
+			},
+			"dns_servers": {
+				Type:        schema.TypeList,
+				Description: "DNS servers handed out, empty uses the interface address",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsIPv4Address,
+				},
+			},
+			"gateway": {
+				Type:         schema.TypeString,
+				Description:  "Gateway handed out, empty uses the interface address",
+				Optional:     true,
+				ValidateFunc: validation.Any(validation.StringIsEmpty, validation.IsIPv4Address),
+			},
+			"domain": {
+				Type:        schema.TypeString,
+				Description: "Domain name handed out",
+				Optional:    true,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "Description of the subnet",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func resourceDHCPServerCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	_, subnet, err := net.ParseCIDR(d.Get("subnet").(string))
+	if err != nil {
+		// Unknown until apply, e.g. when referencing another resource
+		return nil
+	}
+
+	for _, key := range []string{"range_from", "range_to", "gateway"} {
+		ip := net.ParseIP(d.Get(key).(string))
+		if ip != nil && !subnet.Contains(ip) {
+			return fmt.Errorf("%s %s is not inside subnet %s", key, ip, subnet)
+		}
+	}
+
+	from := net.ParseIP(d.Get("range_from").(string))
+	to := net.ParseIP(d.Get("range_to").(string))
+
+	if from != nil && to != nil && bytes.Compare(from.To16(), to.To16()) > 0 {
+		return fmt.Errorf("range_from %s is after range_to %s", from, to)
+	}
+
+	iface := d.Get("interface").(string)
+	if meta == nil || !d.NewValueKnown("interface") || iface == "" {
+		return nil
+	}
+
+	matched, err := keaSubnetInterface(meta.(*providerMeta), subnet.String())
+	if err != nil {
+		return err
+	}
+
+	if matched != "" && matched != iface {
+		return fmt.Errorf("subnet %s is on interface %s, not %s", subnet, matched, iface)
+	}
+
+	return nil
+}
+
+func resourceDHCPServerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	diags := keaSubnetItem.read(ctx, d, meta)
+	if diags.HasError() || d.Id() == "" {
+		return diags
+	}
+
+	dhcpv4, err := keaDHCPv4Get(meta.(*providerMeta))
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch Kea general settings")
+
+		return diag.FromErr(err)
+	}
+
+	iface, err := keaSubnetInterface(meta.(*providerMeta), d.Get("subnet").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Interfaces without an address can not be matched, e.g. while down
+	if iface == "" {
+		iface = d.Get("interface").(string)
+	}
+
+	// The subnet is only served while Kea listens on the interface
+	if !bool(dhcpv4.General.Enabled) || !keaListensOn(dhcpv4, iface) {
+		iface = ""
+	}
+
+	err = d.Set("interface", iface)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceDHCPServerCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMeta)
+
+	item, err := keaSubnetItem.expand(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The subnet is added first, a failed add must not leave Kea listening
+	id, err := mvcAdd(m.client, keaSubnetItem.endpoint("add"), keaSubnetItem.key, item)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id.String())
+
+	err = keaInterfaceSet(m, d.Id(), "", d.Get("interface").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = keaSubnetItem.apply(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceDHCPServerRead(ctx, d, meta)
+}
+
+func resourceDHCPServerUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMeta)
+
+	item, err := keaSubnetItem.expand(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = mvcSet(m.client, keaSubnetItem.endpoint("set", d.Id()), keaSubnetItem.key, item)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	oldIface, newIface := d.GetChange("interface")
+
+	err = keaInterfaceSet(m, d.Id(), oldIface.(string), newIface.(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = keaSubnetItem.apply(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceDHCPServerRead(ctx, d, meta)
+}
+
+func resourceDHCPServerDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMeta)
+
+	var diags diag.Diagnostics
+
+	err := mvcDelete(m.client, keaSubnetItem.endpoint("del", d.Id()))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = keaInterfaceSet(m, d.Id(), d.Get("interface").(string), "")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = keaSubnetItem.apply(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+func keaDHCPv4Get(meta *providerMeta) (*keaDHCPv4, error) {
+	dhcpv4 := keaDHCPv4{}
+
+	err := mvcGet(meta.client, "kea/dhcpv4/get", "dhcpv4", &dhcpv4)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dhcpv4, nil
+}
+
+func keaListensOn(dhcpv4 *keaDHCPv4, iface string) bool {
+	for _, i := range dhcpv4.General.Interfaces {
+		if i == iface {
+			return true
+		}
+	}
+
+	return false
+}
+
+// keaInterfaceSet replaces remove with add in the interfaces Kea listens on
+// for the subnet with the given id. remove is kept while other subnets are
+// still served on it. Kea is enabled while it listens on any interface, the
+// change is applied by the reconfigure of the subnet.
+func keaInterfaceSet(meta *providerMeta, id string, remove string, add string) error {
+	if remove == add {
+		return nil
+	}
+
+	keaGeneralMutex.Lock()
+	defer keaGeneralMutex.Unlock()
+
+	if remove != "" {
+		inUse, err := keaInterfaceInUse(meta, remove, id)
+		if err != nil {
+			return err
+		}
+
+		if inUse {
+			log.Printf("[DEBUG] Kea keeps listening on %s, other subnets are served on it", remove)
+
+			remove = ""
+		}
+	}
+
+	dhcpv4, err := keaDHCPv4Get(meta)
+	if err != nil {
+		return err
+	}
+
+	interfaces := mvcList{}
+
+	for _, iface := range dhcpv4.General.Interfaces {
+		if iface != remove && iface != add {
+			interfaces = append(interfaces, iface)
+		}
+	}
+
+	if add != "" {
+		interfaces = append(interfaces, add)
+	}
+
+	general := keaGeneral{
+		Enabled:    mvcBool(len(interfaces) > 0),
+		Interfaces: interfaces,
+	}
+
+	return mvcSet(meta.client, "kea/dhcpv4/set", "dhcpv4", keaDHCPv4{General: general})
+}
+
+// keaInterfaceInUse reports whether a subnet other than the one with the
+// given id is served on iface. Kea subnets do not store their interface,
+// Kea serves a subnet on the interface whose address is inside it.
+func keaInterfaceInUse(meta *providerMeta, iface string, id string) (bool, error) {
+	addresses, err := keaInterfaceAddresses(meta)
+	if err != nil {
+		return false, err
+	}
+
+	address, ok := addresses[iface]
+
+	// Without an address the subnets can not be matched, keep listening
+	if !ok {
+		return true, nil
+	}
+
+	rows := []keaSubnetRow{}
+
+	err = keaSubnetItem.search(meta, &rows)
+	if err != nil {
+		return false, err
+	}
+
+	for _, row := range rows {
+		_, subnet, err := net.ParseCIDR(row.Subnet)
+		if err == nil && row.UUID != id && subnet.Contains(address) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// keaSubnetInterface returns the interface whose address is inside subnet,
+// which is the interface Kea serves the subnet on. It is empty when no
+// interface matches.
+func keaSubnetInterface(meta *providerMeta, subnet string) (string, error) {
+	_, network, err := net.ParseCIDR(subnet)
+	if err != nil {
+		return "", nil
+	}
+
+	addresses, err := keaInterfaceAddresses(meta)
+	if err != nil {
+		return "", err
+	}
+
+	ifaces := make([]string, 0, len(addresses))
+	for iface := range addresses {
+		ifaces = append(ifaces, iface)
+	}
+
+	sort.Strings(ifaces)
+
+	for _, iface := range ifaces {
+		if network.Contains(addresses[iface]) {
+			return iface, nil
+		}
+	}
+
+	return "", nil
+}
+
+// keaInterfaceAddresses returns the IPv4 address of each interface that has one.
+func keaInterfaceAddresses(meta *providerMeta) (map[string]net.IP, error) {
+	interfaces, err := interfaceInfoList(meta.client)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := map[string]net.IP{}
+
+	for _, info := range interfaces {
+		if address := net.ParseIP(strings.SplitN(info.Addr4, "/", 2)[0]); address != nil {
+			addresses[info.Identifier] = address
+		}
+	}
+
+	return addresses, nil
+}
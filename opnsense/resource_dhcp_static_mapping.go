@@ -0,0 +1,165 @@
+package opnsense
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+type keaReservation struct {
+	Subnet      mvcOption `json:"subnet"`
+	IPAddress   string    `json:"ip_address"`
+	HWAddress   string    `json:"hw_address"`
+	Hostname    string    `json:"hostname"`
+	Description string    `json:"description"`
+}
+
+var keaReservationItem = &mvcItem{
+	controller:  "kea/dhcpv4",
+	name:        "Reservation",
+	key:         "reservation",
+	reconfigure: mvcReconfigure("kea/service/reconfigure"),
+
+	newItem: func() interface{} { return &keaReservation{} },
+	expand: func(d *schema.ResourceData) (interface{}, error) {
+		return &keaReservation{
+			Subnet:      mvcOption(d.Get("server").(string)),
+			IPAddress:   d.Get("ip_address").(string),
+			HWAddress:   d.Get("mac_address").(string),
+			Hostname:    d.Get("hostname").(string),
+			Description: d.Get("description").(string),
+		}, nil
+	},
+	flatten: func(item interface{}) map[string]interface{} {
+		reservation := item.(*keaReservation)
+
+		return map[string]interface{}{
+			"server":      string(reservation.Subnet),
+			"ip_address":  reservation.IPAddress,
+			"mac_address": reservation.HWAddress,
+			"hostname":    reservation.Hostname,
+			"description": reservation.Description,
+		}
+	},
+}
+
+func resourceDHCPStaticMapping() *schema.Resource {
+	return &schema.Resource{
+		Description: "A fixed Kea DHCPv4 lease for a MAC address. Kea stores reservations with " +
+			"their subnet instead of an interface, so the mapping references the opnsense_dhcp_server " +
+			"of the subnet rather than an interface.",
+
+		CreateContext: keaReservationItem.create,
+		ReadContext:   keaReservationItem.read,
+		UpdateContext: keaReservationItem.update,
+		DeleteContext: keaReservationItem.delete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		CustomizeDiff: resourceDHCPStaticMappingCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"server": {
+				Type:         schema.TypeString,
+				Description:  "ID of the opnsense_dhcp_server serving the interface",
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"mac_address": {
+				Type:         schema.TypeString,
+				Description:  "MAC address of the host",
+				Required:     true,
+				ValidateFunc: validation.IsMACAddress,
+			},
+			"ip_address": {
+				Type:         schema.TypeString,
+				Description:  "Address leased to the host, must be inside the subnet of the server",
+				Required:     true,
+				ValidateFunc: validation.IsIPv4Address,
+			},
+			"hostname": {
+				Type:        schema.TypeString,
+				Description: "Host name handed out to the host",
+				Optional:    true,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "Description of the mapping",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func resourceDHCPStaticMappingCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	ip := net.ParseIP(d.Get("ip_address").(string))
+
+	if meta == nil || ip == nil {
+		return nil
+	}
+
+	if d.HasChange("ip_address") || d.HasChange("mac_address") {
+		err := checkDHCPLeaseCollision(meta.(*providerMeta), ip.String(), d.Get("mac_address").(string))
+		if err != nil {
+			return err
+		}
+	}
+
+	// Unknown until apply, e.g. when the server is created in the same apply
+	if !d.NewValueKnown("server") {
+		return nil
+	}
+
+	id, err := uuid.FromString(d.Get("server").(string))
+	if err != nil {
+		return err
+	}
+
+	item, err := keaSubnetItem.get(meta.(*providerMeta), id)
+	if err != nil {
+		return err
+	}
+
+	_, subnet, err := net.ParseCIDR(item.(*keaSubnet).Subnet)
+	if err != nil {
+		return err
+	}
+
+	if !subnet.Contains(ip) {
+		return fmt.Errorf("ip_address %s is not inside subnet %s of the server", ip, subnet)
+	}
+
+	return nil
+}
+
+// checkDHCPLeaseCollision fails when ip is actively leased to another host
+// than the one with the given MAC address.
+func checkDHCPLeaseCollision(meta *providerMeta, ip string, mac string) error {
+	leases, err := keaLeaseList(meta.client)
+	if err != nil {
+		return err
+	}
+
+	for _, lease := range leases {
+		// Kea keeps expired leases until they are reclaimed
+		_, end := keaLeaseTimes(lease)
+
+		if lease.Address != ip || keaLeaseState(lease.State) != "active" || end.Before(time.Now()) {
+			continue
+		}
+
+		if !strings.EqualFold(lease.HWAddress, mac) {
+			return fmt.Errorf("ip_address %s is actively leased to %s (%s)", ip, lease.HWAddress, lease.Hostname)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package opnsense
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testDHCPStaticMappingResource(name string, ip string) string {
+	return fmt.Sprintf(`
+resource "opnsense_dhcp_server" "%s" {
+  interface   = "lan"
+  subnet      = "192.168.1.0/24"
+  range_from  = "192.168.1.100"
+  range_to    = "192.168.1.199"
+  dns_servers = ["192.168.1.1"]
+  domain      = "example.internal"
+  description = "%s"
+}
+
+resource "opnsense_dhcp_static_mapping" "%s" {
+  server      = opnsense_dhcp_server.%s.id
+  mac_address = "02:00:00:12:34:56"
+  ip_address  = "%s"
+  hostname    = "%s"
+}
+`, name, name, name, name, ip, name)
+}
+
+func testDHCPServerInvalidRangeResource(name string) string {
+	return fmt.Sprintf(`
+resource "opnsense_dhcp_server" "%s" {
+  interface  = "lan"
+  subnet     = "192.168.1.0/24"
+  range_from = "192.168.1.199"
+  range_to   = "192.168.1.100"
+}
+`, name)
+}
+
+func testDHCPLeasesData(name string) string {
+	return testDHCPStaticMappingResource(name, "192.168.1.20") + `
+data "opnsense_dhcp_leases" "lan" {
+  interface = "lan"
+}
+`
+}
+
+func testAccDHCPStaticMappingResourceDestroy(s *terraform.State) error {
+	items := map[string]*mvcItem{
+		"opnsense_dhcp_server":         keaSubnetItem,
+		"opnsense_dhcp_static_mapping": keaReservationItem,
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		item, ok := items[rs.Type]
+		if !ok {
+			continue
+		}
+
+		err := testAccCheckMvcItemDestroyed(item, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestDHCPStaticMapping_subnet(t *testing.T) {
+	rName := fmt.Sprintf("a%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccDHCPStaticMappingResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testDHCPStaticMappingResource(rName, "192.168.1.20"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						fmt.Sprintf("opnsense_dhcp_server.%s", rName),
+						"interface",
+						"lan",
+					),
+					resource.TestCheckResourceAttrPair(
+						fmt.Sprintf("opnsense_dhcp_static_mapping.%s", rName),
+						"server",
+						fmt.Sprintf("opnsense_dhcp_server.%s", rName),
+						"id",
+					),
+				),
+			},
+			{
+				Config: testDHCPLeasesData(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"data.opnsense_dhcp_leases.lan",
+						"lease.0.ip_address",
+					),
+					resource.TestCheckResourceAttrSet(
+						"data.opnsense_dhcp_leases.lan",
+						"lease.0.mac_address",
+					),
+					resource.TestCheckResourceAttr(
+						"data.opnsense_dhcp_leases.lan",
+						"lease.0.interface",
+						"lan",
+					),
+				),
+			},
+			{
+				Config:      testDHCPStaticMappingResource(rName, "10.99.0.20"),
+				ExpectError: regexp.MustCompile("is not inside subnet 192.168.1.0/24"),
+			},
+			{
+				Config:      testDHCPServerInvalidRangeResource(rName),
+				ExpectError: regexp.MustCompile("range_from 192.168.1.199 is after range_to 192.168.1.100"),
+			},
+			{
+				ResourceName:      fmt.Sprintf("opnsense_dhcp_server.%s", rName),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -71,7 +71,7 @@ func resourceFirewallAlias() *schema.Resource {
 func resourceFirewallAliasRead(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[TRACE] Getting OPNsense client from meta")
 
-	c := meta.(*opnsense.Client)
+	c := meta.(*providerMeta).client
 
 	log.Printf("[TRACE] Converting ID to UUID")
 
@@ -153,7 +153,7 @@ func resourceFirewallAliasRead(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceFirewallAliasCreate(d *schema.ResourceData, meta interface{}) error {
-	c := meta.(*opnsense.Client)
+	c := meta.(*providerMeta).client
 	alias := opnsense.AliasFormat{}
 
 	err := prepareFirewallAliasConfiguration(d, &alias)
@@ -193,7 +193,7 @@ func resourceFirewallAliasCreate(d *schema.ResourceData, meta interface{}) error
 
 func resourceFirewallAliasUpdate(d *schema.ResourceData, meta interface{}) error {
 	// TODO don"t update the alias if only the parent field is modified
-	c := meta.(*opnsense.Client)
+	c := meta.(*providerMeta).client
 
 	elmUUID, err := uuid.FromString(d.Id())
 	if err != nil {
@@ -253,7 +253,7 @@ func resourceFirewallAliasUpdate(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceFirewallAliasDelete(d *schema.ResourceData, meta interface{}) error {
-	c := meta.(*opnsense.Client)
+	c := meta.(*providerMeta).client
 
 	uuid, err := uuid.FromString(d.Id())
 	if err != nil {
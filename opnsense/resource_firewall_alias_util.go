@@ -33,7 +33,7 @@ func resourceFirewallAliasUtil() *schema.Resource {
 }
 
 func resourceFirewallAliasUtilRead(d *schema.ResourceData, meta interface{}) error {
-	c := meta.(*opnsense.Client)
+	c := meta.(*providerMeta).client
 
 	name := d.Get("name").(string)
 
@@ -77,7 +77,7 @@ func resourceFirewallAliasUtilRead(d *schema.ResourceData, meta interface{}) err
 }
 
 func resourceFirewallAliasUtilCreate(d *schema.ResourceData, meta interface{}) error {
-	c := meta.(*opnsense.Client)
+	c := meta.(*providerMeta).client
 
 	name := d.Get("name").(string)
 	address := d.Get("address").(string)
@@ -96,7 +96,7 @@ func resourceFirewallAliasUtilCreate(d *schema.ResourceData, meta interface{}) e
 }
 
 func resourceFirewallAliasUtilUpdate(d *schema.ResourceData, meta interface{}) error {
-	c := meta.(*opnsense.Client)
+	c := meta.(*providerMeta).client
 	conf := opnsense.AliasUtilsSet{}
 
 	oldAddress := d.Get("address")
@@ -134,7 +134,7 @@ func resourceFirewallAliasUtilUpdate(d *schema.ResourceData, meta interface{}) e
 }
 
 func resourceFirewallAliasUtilDelete(d *schema.ResourceData, meta interface{}) error {
-	c := meta.(*opnsense.Client)
+	c := meta.(*providerMeta).client
 
 	name := d.Get("name").(string)
 	conf := opnsense.AliasUtilsSet{
@@ -103,8 +103,9 @@ func resourceFirewallFilterRule() *schema.Resource {
 				Required: true,
 			},
 			"gateway": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Name of the gateway for policy routing, e.g. opnsense_routing_gateway.wan2.name",
 			},
 			"log": {
 				Type:     schema.TypeBool,
@@ -124,7 +125,7 @@ func resourceFirewallFilterRuleRead(ctx context.Context, d *schema.ResourceData,
 
 	var diags diag.Diagnostics
 
-	c := meta.(*opnsense.Client)
+	c := meta.(*providerMeta).client
 
 	log.Printf("[TRACE] Converting ID to UUID")
 
@@ -163,7 +164,7 @@ func resourceFirewallFilterRuleRead(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceFirewallFilterRuleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*opnsense.Client)
+	c := meta.(*providerMeta).client
 
 	rule := opnsense.FilterRule{}
 	ruleMap := make(map[string]interface{})
@@ -186,7 +187,7 @@ func resourceFirewallFilterRuleCreate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceFirewallFilterRuleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*opnsense.Client)
+	c := meta.(*providerMeta).client
 
 	rule := opnsense.FilterRule{}
 	ruleMap := make(map[string]interface{})
@@ -209,7 +210,7 @@ func resourceFirewallFilterRuleUpdate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceFirewallFilterRuleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*opnsense.Client)
+	c := meta.(*providerMeta).client
 
 	var diags diag.Diagnostics
 
@@ -7,7 +7,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	"github.com/kradalby/opnsense-go/opnsense"
 )
 
 func testFirewallFilterRuleResource(name string) string {
@@ -33,7 +32,7 @@ resource "opnsense_firewall_filter_rule" "%s" {
 }
 
 func testAccFirewallFilterRuleResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*opnsense.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	rules, err := c.FirewallFilterRuleSearch()
 	if err != nil {
@@ -2,6 +2,7 @@ package opnsense
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"time"
 
@@ -27,6 +28,13 @@ func resourceFirmware() *schema.Resource {
 		},
 
 		Schema: map[string]*schema.Schema{
+			"exclusive": {
+				Type: schema.TypeBool,
+				Description: "Manage all installed plugins, plugins missing from the configuration are removed. " +
+					"When false, plugins not in the configuration are ignored, switching to false removes no plugins",
+				Optional: true,
+				Default:  true,
+			},
 			"plugin": {
 				Type:        schema.TypeSet,
 				Optional:    true,
@@ -94,7 +102,7 @@ func resourceFirmwareRead(ctx context.Context, d *schema.ResourceData, meta inte
 
 	var diags diag.Diagnostics
 
-	c := meta.(*opnsense.Client)
+	c := meta.(*providerMeta).client
 
 	installedPlugins, err := c.FirmwareInstalledPluginsList()
 	if err != nil {
@@ -104,10 +112,24 @@ func resourceFirmwareRead(ctx context.Context, d *schema.ResourceData, meta inte
 		return diag.FromErr(err)
 	}
 
-	installedPluginMaps := make([]map[string]interface{}, len(installedPlugins))
+	exclusive := d.Get("exclusive").(bool)
+	managed := map[string]bool{}
+
+	for _, plug := range d.Get("plugin").(*schema.Set).List() {
+		managed[plug.(map[string]interface{})["name"].(string)] = true
+	}
+
+	installedPluginMaps := make([]map[string]interface{}, 0, len(installedPlugins))
+
+	for _, plugin := range installedPlugins {
+		pluginMap := opnsense.StructToMap(plugin)
 
-	for index, plugin := range installedPlugins {
-		installedPluginMaps[index] = opnsense.StructToMap(plugin)
+		// Plugins installed outside of this resource are not drift when not exclusive
+		if !exclusive && !managed[fmt.Sprint(pluginMap["name"])] {
+			continue
+		}
+
+		installedPluginMaps = append(installedPluginMaps, pluginMap)
 	}
 
 	if err := d.Set("plugin", installedPluginMaps); err != nil {
@@ -120,7 +142,7 @@ func resourceFirmwareRead(ctx context.Context, d *schema.ResourceData, meta inte
 }
 
 func resourceFirmwareCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*opnsense.Client)
+	c := meta.(*providerMeta).client
 
 	added := d.Get("plugin").(*schema.Set)
 
@@ -133,7 +155,7 @@ func resourceFirmwareCreate(ctx context.Context, d *schema.ResourceData, meta in
 }
 
 func resourceFirmwareUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*opnsense.Client)
+	c := meta.(*providerMeta).client
 
 	if d.HasChange("plugin") {
 		oldRaw, newRaw := d.GetChange("plugin")
@@ -143,6 +165,14 @@ func resourceFirmwareUpdate(ctx context.Context, d *schema.ResourceData, meta in
 		added := new.Difference(old)
 		removed := old.Difference(new)
 
+		// The plugins were refreshed while exclusive, so the old set also
+		// holds the plugins that are not managed by this resource
+		if d.HasChange("exclusive") && !d.Get("exclusive").(bool) {
+			log.Printf("[DEBUG] No longer exclusive, keeping %d plugins missing from the configuration", removed.Len())
+
+			removed = schema.NewSet(old.F, nil)
+		}
+
 		diags := installPlugins(ctx, d, c, added)
 		if diags.HasError() {
 			return diags
@@ -158,7 +188,7 @@ func resourceFirmwareUpdate(ctx context.Context, d *schema.ResourceData, meta in
 }
 
 func resourceFirmwareDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*opnsense.Client)
+	c := meta.(*providerMeta).client
 
 	removed := d.Get("plugin").(*schema.Set)
 
@@ -173,7 +203,7 @@ func resourceFirmwareDelete(ctx context.Context, d *schema.ResourceData, meta in
 	return diags
 }
 
-func statusStateConf(d *schema.ResourceData, client *opnsense.Client) *resource.StateChangeConf {
+func statusStateConf(client *opnsense.Client, timeout time.Duration) *resource.StateChangeConf {
 	createStateConf := &resource.StateChangeConf{
 		Pending: []string{
 			opnsense.StatusRunning,
@@ -189,7 +219,7 @@ func statusStateConf(d *schema.ResourceData, client *opnsense.Client) *resource.
 
 			return resp, resp.Status, nil
 		},
-		Timeout: d.Timeout(schema.TimeoutCreate),
+		Timeout: timeout,
 
 		Delay:                     10 * time.Second,
 		MinTimeout:                5 * time.Second,
@@ -215,7 +245,7 @@ func installPlugins(ctx context.Context,
 			return diag.FromErr(err)
 		}
 
-		upgradeChecker := statusStateConf(d, c)
+		upgradeChecker := statusStateConf(c, d.Timeout(schema.TimeoutCreate))
 
 		_, err = upgradeChecker.WaitForStateContext(ctx)
 		if err != nil {
@@ -242,7 +272,7 @@ func removePlugins(ctx context.Context,
 			return diag.FromErr(err)
 		}
 
-		upgradeChecker := statusStateConf(d, c)
+		upgradeChecker := statusStateConf(c, d.Timeout(schema.TimeoutCreate))
 
 		_, err = upgradeChecker.WaitForStateContext(ctx)
 		if err != nil {
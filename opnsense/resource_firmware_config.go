@@ -0,0 +1,160 @@
+package opnsense
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/kradalby/opnsense-go/opnsense"
+)
+
+const firmwareConfigID = "firmware_config"
+
+type firmwareConfig struct {
+	Mirror       string `json:"mirror"`
+	Flavour      string `json:"flavour"`
+	Type         string `json:"type"`
+	Subscription string `json:"subscription"`
+}
+
+func resourceFirmwareConfig() *schema.Resource {
+	return &schema.Resource{
+		Description: "Firmware mirror, flavour and release type. There is only one per OPNsense, " +
+			"destroying the resource resets all settings to the OPNsense defaults. " +
+			"Make opnsense_firmware depend on this resource to install plugins from the configured mirror.",
+
+		CreateContext: resourceFirmwareConfigUpdate,
+		ReadContext:   resourceFirmwareConfigRead,
+		UpdateContext: resourceFirmwareConfigUpdate,
+		DeleteContext: resourceFirmwareConfigDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"mirror": {
+				Type:         schema.TypeString,
+				Description:  "URL of the package mirror, empty uses the default mirror",
+				Optional:     true,
+				ValidateFunc: validation.Any(validation.StringIsEmpty, validation.IsURLWithHTTPorHTTPS),
+			},
+			"flavour": {
+				Type:        schema.TypeString,
+				Description: "Package flavour, e.g. empty for OpenSSL or libressl",
+				Optional:    true,
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Description: "Release type, e.g. empty for community, -devel or -business",
+				Optional:    true,
+			},
+			"subscription": {
+				Type:        schema.TypeString,
+				Description: "Subscription key for the business edition mirror",
+				Optional:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func resourceFirmwareConfigRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[TRACE] Getting OPNsense client from meta")
+
+	var diags diag.Diagnostics
+
+	c := meta.(*providerMeta).client
+
+	config := firmwareConfig{}
+
+	err := c.GetAndUnmarshal("core/firmware/getFirmwareConfig", &config)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch firmware configuration")
+
+		return diag.FromErr(err)
+	}
+
+	values := map[string]interface{}{
+		"mirror":       config.Mirror,
+		"flavour":      config.Flavour,
+		"type":         config.Type,
+		"subscription": config.Subscription,
+	}
+
+	err = setResourceData(d, values)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(firmwareConfigID)
+
+	return diags
+}
+
+func resourceFirmwareConfigUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*providerMeta).client
+
+	config := firmwareConfig{
+		Mirror:       d.Get("mirror").(string),
+		Flavour:      d.Get("flavour").(string),
+		Type:         d.Get("type").(string),
+		Subscription: d.Get("subscription").(string),
+	}
+
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	if d.IsNewResource() {
+		timeout = d.Timeout(schema.TimeoutCreate)
+	}
+
+	err := setFirmwareConfig(ctx, c, config, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceFirmwareConfigRead(ctx, d, meta)
+}
+
+func resourceFirmwareConfigDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*providerMeta).client
+
+	var diags diag.Diagnostics
+
+	err := setFirmwareConfig(ctx, c, firmwareConfig{}, d.Timeout(schema.TimeoutDelete))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// setFirmwareConfig saves config and refreshes the package catalog so
+// following plugin installs resolve against the new mirror.
+func setFirmwareConfig(ctx context.Context, c *opnsense.Client, config firmwareConfig, timeout time.Duration) error {
+	api := "core/firmware/setFirmwareConfig"
+	response := mvcResponse{}
+
+	err := c.PostAndMarshal(api, config, &response)
+	if err != nil {
+		return err
+	}
+
+	if !strings.EqualFold(response.Status, mvcStatusOk) {
+		return fmt.Errorf("%s returned status %q: %w", api, response.Status, ErrStatusNotOk)
+	}
+
+	return firmwareCheck(ctx, c, timeout)
+}
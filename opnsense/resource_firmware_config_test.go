@@ -0,0 +1,71 @@
+package opnsense
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testFirmwareConfigResource(name string, mirror string) string {
+	return fmt.Sprintf(`
+resource "opnsense_firmware_config" "%s" {
+  mirror = "%s"
+}
+
+resource "opnsense_firmware" "%s" {
+  plugin {
+    name      = "os-iperf"
+    installed = true
+  }
+
+  depends_on = [opnsense_firmware_config.%s]
+}
+`, name, mirror, name, name)
+}
+
+func testAccFirmwareConfigResourceDestroy(s *terraform.State) error {
+	c := testAccProvider.Meta().(*providerMeta).client
+
+	config := firmwareConfig{}
+
+	err := c.GetAndUnmarshal("core/firmware/getFirmwareConfig", &config)
+	if err != nil {
+		return err
+	}
+
+	if config.Mirror != "" {
+		return fmt.Errorf("Mirror is not reset, %s", config.Mirror)
+	}
+
+	return nil
+}
+
+func TestFirmwareConfig_basic(t *testing.T) {
+	rName := fmt.Sprintf("a%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccFirmwareConfigResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testFirmwareConfigResource(rName, "https://pkg.opnsense.org"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						fmt.Sprintf("opnsense_firmware_config.%s", rName),
+						"mirror",
+						"https://pkg.opnsense.org",
+					),
+					resource.TestCheckResourceAttr(
+						fmt.Sprintf("opnsense_firmware.%s", rName),
+						"plugin.#",
+						"1",
+					),
+				),
+			},
+		},
+	})
+}
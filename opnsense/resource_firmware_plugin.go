@@ -0,0 +1,229 @@
+package opnsense
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/kradalby/opnsense-go/opnsense"
+)
+
+func resourceFirmwarePlugin() *schema.Resource {
+	return &schema.Resource{
+		Description: "A single plugin installed to OPNsense. Use together with " +
+			"opnsense_firmware only when that resource has exclusive set to false.",
+
+		CreateContext: resourceFirmwarePluginCreate,
+		ReadContext:   resourceFirmwarePluginRead,
+		UpdateContext: resourceFirmwarePluginUpdate,
+		DeleteContext: resourceFirmwarePluginDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(45 * time.Minute),
+			Update: schema.DefaultTimeout(45 * time.Minute),
+			Delete: schema.DefaultTimeout(45 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Description: "Package name of the plugin, e.g. os-wireguard",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"locked": {
+				Type:        schema.TypeBool,
+				Description: "Lock the plugin to prevent it from being updated",
+				Optional:    true,
+				Default:     false,
+			},
+			"reinstall_trigger": {
+				Type:        schema.TypeString,
+				Description: "Arbitrary value, the plugin is reinstalled whenever it changes",
+				Optional:    true,
+			},
+			"version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"comment": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"repository": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceFirmwarePluginRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[TRACE] Getting OPNsense client from meta")
+
+	var diags diag.Diagnostics
+
+	c := meta.(*providerMeta).client
+
+	installedPlugins, err := c.FirmwareInstalledPluginsList()
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch installed plugins")
+
+		return diag.FromErr(err)
+	}
+
+	for _, plugin := range installedPlugins {
+		pluginMap := opnsense.StructToMap(plugin)
+
+		if fmt.Sprint(pluginMap["name"]) != d.Id() {
+			continue
+		}
+
+		values := map[string]interface{}{
+			"name":       d.Id(),
+			"locked":     fmt.Sprint(pluginMap["locked"]) == "1",
+			"version":    fmt.Sprint(pluginMap["version"]),
+			"comment":    fmt.Sprint(pluginMap["comment"]),
+			"repository": fmt.Sprint(pluginMap["repository"]),
+		}
+
+		err = setResourceData(d, values)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		return diags
+	}
+
+	log.Printf("[DEBUG] Plugin %s is not installed", d.Id())
+
+	d.SetId("")
+
+	return diags
+}
+
+func resourceFirmwarePluginCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*providerMeta).client
+
+	name := d.Get("name").(string)
+	timeout := d.Timeout(schema.TimeoutCreate)
+
+	err := c.FirmwareInstall(name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = statusStateConf(c, timeout).WaitForStateContext(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(name)
+
+	if d.Get("locked").(bool) {
+		err = firmwarePluginAction(ctx, c, "lock", name, timeout)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceFirmwarePluginRead(ctx, d, meta)
+}
+
+func resourceFirmwarePluginUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*providerMeta).client
+
+	name := d.Id()
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	locked := d.Get("locked").(bool)
+
+	// A locked package can not be reinstalled
+	if d.HasChange("reinstall_trigger") {
+		if old, _ := d.GetChange("locked"); old.(bool) {
+			err := firmwarePluginAction(ctx, c, "unlock", name, timeout)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
+		err := firmwarePluginAction(ctx, c, "reinstall", name, timeout)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if locked {
+			err = firmwarePluginAction(ctx, c, "lock", name, timeout)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	} else if d.HasChange("locked") {
+		action := "unlock"
+		if locked {
+			action = "lock"
+		}
+
+		err := firmwarePluginAction(ctx, c, action, name, timeout)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceFirmwarePluginRead(ctx, d, meta)
+}
+
+func resourceFirmwarePluginDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*providerMeta).client
+
+	var diags diag.Diagnostics
+
+	name := d.Id()
+	timeout := d.Timeout(schema.TimeoutDelete)
+
+	if d.Get("locked").(bool) {
+		err := firmwarePluginAction(ctx, c, "unlock", name, timeout)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	err := c.FirmwareRemove(name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = statusStateConf(c, timeout).WaitForStateContext(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// firmwarePluginAction runs a package action like lock or reinstall and
+// waits for the firmware backend to finish it.
+func firmwarePluginAction(ctx context.Context,
+	c *opnsense.Client,
+	action string,
+	name string,
+	timeout time.Duration) error {
+	response := mvcResponse{}
+
+	err := c.PostAndMarshal(fmt.Sprintf("core/firmware/%s/%s", action, name), struct{}{}, &response)
+	if err != nil {
+		return err
+	}
+
+	_, err = statusStateConf(c, timeout).WaitForStateContext(ctx)
+
+	return err
+}
@@ -0,0 +1,130 @@
+package opnsense
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/kradalby/opnsense-go/opnsense"
+)
+
+func testFirmwareSinglePluginResource(name string, exclusive bool, plugins []string, locked bool) string {
+	return fmt.Sprintf(`
+resource "opnsense_firmware" "%s" {
+  exclusive = %t
+
+  dynamic "plugin" {
+    for_each = ["%s"]
+
+    content {
+      name      = plugin.value
+      installed = true
+    }
+  }
+}
+
+resource "opnsense_firmware_plugin" "%s" {
+  name   = "os-vmware"
+  locked = %t
+}
+`, name, exclusive, strings.Join(plugins, `", "`), name, locked)
+}
+
+// testAccCheckFirmwarePluginInstalled checks that name is still installed.
+func testAccCheckFirmwarePluginInstalled(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		c := testAccProvider.Meta().(*providerMeta).client
+
+		installedPlugins, err := c.FirmwareInstalledPluginsList()
+		if err != nil {
+			return err
+		}
+
+		for _, plugin := range installedPlugins {
+			if fmt.Sprint(opnsense.StructToMap(plugin)["name"]) == name {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("Plugin %s is not installed", name)
+	}
+}
+
+func testAccFirmwareSinglePluginResourceDestroy(s *terraform.State) error {
+	c := testAccProvider.Meta().(*providerMeta).client
+
+	installedPlugins, err := c.FirmwareInstalledPluginsList()
+	if err != nil {
+		return err
+	}
+
+	for _, plugin := range installedPlugins {
+		if name := fmt.Sprint(opnsense.StructToMap(plugin)["name"]); name == "os-vmware" {
+			return fmt.Errorf("Plugin %s is not uninstalled", name)
+		}
+	}
+
+	return nil
+}
+
+func TestFirmwareSinglePlugin_basic(t *testing.T) {
+	rName := fmt.Sprintf("a%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccFirmwareSinglePluginResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testFirmwareSinglePluginResource(rName, false, []string{"os-iperf"}, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						fmt.Sprintf("opnsense_firmware.%s", rName),
+						"plugin.#",
+						"1",
+					),
+					resource.TestCheckResourceAttrSet(
+						fmt.Sprintf("opnsense_firmware_plugin.%s", rName),
+						"version",
+					),
+				),
+			},
+			{
+				Config: testFirmwareSinglePluginResource(rName, false, []string{"os-iperf"}, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						fmt.Sprintf("opnsense_firmware_plugin.%s", rName),
+						"locked",
+						"true",
+					),
+				),
+			},
+			{
+				Config: testFirmwareSinglePluginResource(rName, true, []string{"os-iperf", "os-vmware"}, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						fmt.Sprintf("opnsense_firmware.%s", rName),
+						"plugin.#",
+						"2",
+					),
+				),
+			},
+			{
+				// os-vmware is only managed by opnsense_firmware_plugin now
+				// and must survive leaving exclusive mode
+				Config: testFirmwareSinglePluginResource(rName, false, []string{"os-iperf"}, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						fmt.Sprintf("opnsense_firmware.%s", rName),
+						"plugin.#",
+						"1",
+					),
+					testAccCheckFirmwarePluginInstalled("os-vmware"),
+				),
+			},
+		},
+	})
+}
@@ -8,7 +8,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	"github.com/kradalby/opnsense-go/opnsense"
 )
 
 func testFirmwarePluginResource(name string, plugins []string) string {
@@ -32,7 +31,7 @@ resource "opnsense_firmware" "%s" {
 }
 
 func testAccFirmwarePluginResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*opnsense.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	installedPlugins, err := c.FirmwareInstalledPluginsList()
 	if err != nil {
@@ -0,0 +1,377 @@
+package opnsense
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/kradalby/opnsense-go/opnsense"
+)
+
+const (
+	firmwareUpgradeID = "firmware_upgrade"
+
+	firmwarePolicyUpdate  = "update"
+	firmwarePolicyUpgrade = "upgrade"
+
+	firmwareStatusNone      = "none"
+	firmwareStatusUpdate    = "update"
+	firmwareStatusReboot    = "reboot"
+	firmwareStatusRebooting = "rebooting"
+
+	// Every update or upgrade moves at least one release forward, this
+	// only guards against looping forever on a misbehaving mirror.
+	firmwareUpgradeMaxSteps = 10
+)
+
+type firmwareProduct struct {
+	ProductName    string `json:"product_name"`
+	ProductVersion string `json:"product_version"`
+	ProductArch    string `json:"product_arch"`
+	ProductLatest  string `json:"product_latest"`
+}
+
+type firmwarePackage struct {
+	Name           string `json:"name"`
+	Version        string `json:"version"`
+	CurrentVersion string `json:"current_version"`
+	NewVersion     string `json:"new_version"`
+	Repository     string `json:"repository"`
+}
+
+type firmwareStatus struct {
+	Status              string            `json:"status"`
+	StatusMsg           string            `json:"status_msg"`
+	LastCheck           string            `json:"last_check"`
+	NeedsReboot         mvcBool           `json:"needs_reboot"`
+	UpgradeMajorVersion string            `json:"upgrade_major_version"`
+	UpgradePackages     []firmwarePackage `json:"upgrade_packages"`
+	NewPackages         []firmwarePackage `json:"new_packages"`
+	Product             firmwareProduct   `json:"product"`
+}
+
+func resourceFirmwareUpgrade() *schema.Resource {
+	return &schema.Resource{
+		Description: "Updates the OPNsense core to the newest available release. " +
+			"Updates can not be rolled back, destroying the resource only removes it from the state.",
+
+		CreateContext: resourceFirmwareUpgradeCreate,
+		ReadContext:   resourceFirmwareUpgradeRead,
+		UpdateContext: resourceFirmwareUpgradeUpdate,
+		DeleteContext: resourceFirmwareUpgradeDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(90 * time.Minute),
+			Update: schema.DefaultTimeout(90 * time.Minute),
+		},
+
+		CustomizeDiff: resourceFirmwareUpgradeCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"target_version": {
+				Type: schema.TypeString,
+				Description: "Minimum version to update to. OPNsense always installs the newest release " +
+					"available on the mirror, so the installed version may end up newer. " +
+					"When empty, all available updates are installed",
+				Optional: true,
+				ValidateFunc: validation.StringMatch(
+					regexp.MustCompile(`^\d+\.\d+(\.\d+)?(_\d+)?$`),
+					"must be an OPNsense version, e.g. 23.7.5",
+				),
+			},
+			"update_policy": {
+				Type: schema.TypeString,
+				Description: "Either update, which only installs updates within the current major " +
+					"release, or upgrade, which also allows major upgrades",
+				Optional:     true,
+				Default:      firmwarePolicyUpdate,
+				ValidateFunc: validation.StringInSlice([]string{firmwarePolicyUpdate, firmwarePolicyUpgrade}, false),
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Description: "Installed OPNsense version",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceFirmwareUpgradeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[TRACE] Getting OPNsense client from meta")
+
+	var diags diag.Diagnostics
+
+	c := meta.(*providerMeta).client
+
+	status, err := firmwareStatusGet(c)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch firmware status")
+
+		return diag.FromErr(err)
+	}
+
+	err = d.Set("version", status.Product.ProductVersion)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(firmwareUpgradeID)
+
+	return diags
+}
+
+func resourceFirmwareUpgradeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*providerMeta).client
+
+	err := firmwareUpgrade(ctx, c,
+		d.Get("target_version").(string),
+		d.Get("update_policy").(string),
+		d.Timeout(schema.TimeoutCreate),
+	)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceFirmwareUpgradeRead(ctx, d, meta)
+}
+
+func resourceFirmwareUpgradeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*providerMeta).client
+
+	err := firmwareUpgrade(ctx, c,
+		d.Get("target_version").(string),
+		d.Get("update_policy").(string),
+		d.Timeout(schema.TimeoutUpdate),
+	)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceFirmwareUpgradeRead(ctx, d, meta)
+}
+
+func resourceFirmwareUpgradeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	log.Printf("[DEBUG] Firmware updates can not be rolled back, only removing from state")
+
+	d.SetId("")
+
+	return diags
+}
+
+// resourceFirmwareUpgradeCustomizeDiff plans an update when the installed
+// version has fallen behind target_version, e.g. after a restore.
+func resourceFirmwareUpgradeCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	target := d.Get("target_version").(string)
+	version := d.Get("version").(string)
+
+	if target == "" || version == "" {
+		return nil
+	}
+
+	if compareVersions(version, target) < 0 {
+		return d.SetNewComputed("version")
+	}
+
+	return nil
+}
+
+// firmwareUpgrade runs check and update, or upgrade if allowed by policy,
+// until target is reached or nothing more is available.
+func firmwareUpgrade(ctx context.Context, c *opnsense.Client, target string, policy string, timeout time.Duration) error {
+	for step := 0; step < firmwareUpgradeMaxSteps; step++ {
+		err := firmwareCheck(ctx, c, timeout)
+		if err != nil {
+			return err
+		}
+
+		status, err := firmwareStatusGet(c)
+		if err != nil {
+			return err
+		}
+
+		version := status.Product.ProductVersion
+
+		log.Printf("[DEBUG] Firmware status %q, installed version %s", status.Status, version)
+
+		if target != "" && compareVersions(version, target) >= 0 {
+			return nil
+		}
+
+		var action string
+
+		switch {
+		case status.Status == firmwareStatusUpdate:
+			action = firmwarePolicyUpdate
+		case status.UpgradeMajorVersion != "" && policy == firmwarePolicyUpgrade:
+			action = firmwarePolicyUpgrade
+		case target == "":
+			return nil
+		default:
+			return fmt.Errorf(
+				"version %s is installed and no %s to %s is available (%s): %w",
+				version, policy, target, status.StatusMsg, ErrFirmwareTargetNotAvailable,
+			)
+		}
+
+		log.Printf("[DEBUG] Running firmware %s from %s", action, version)
+
+		err = firmwareRun(ctx, c, action, timeout)
+		if err != nil {
+			return err
+		}
+	}
+
+	return fmt.Errorf("target %s not reached after %d steps: %w",
+		target, firmwareUpgradeMaxSteps, ErrFirmwareTargetNotAvailable)
+}
+
+func firmwareStatusGet(c *opnsense.Client) (*firmwareStatus, error) {
+	status := firmwareStatus{}
+
+	err := c.GetAndUnmarshal("core/firmware/status", &status)
+	if err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
+
+// firmwareCheck fetches the list of available updates from the mirror.
+func firmwareCheck(ctx context.Context, c *opnsense.Client, timeout time.Duration) error {
+	err := mvcAction(c, "core/firmware/check")
+	if err != nil {
+		return err
+	}
+
+	_, err = statusStateConf(c, timeout).WaitForStateContext(ctx)
+
+	return err
+}
+
+// firmwareRun starts a firmware action that might reboot OPNsense and waits
+// until it is finished and the API is reachable again.
+func firmwareRun(ctx context.Context, c *opnsense.Client, action string, timeout time.Duration) error {
+	err := mvcAction(c, "core/firmware/"+action)
+	if err != nil {
+		return err
+	}
+
+	_, err = rebootStateConf(c, timeout).WaitForStateContext(ctx)
+
+	return err
+}
+
+func rebootStateConf(client *opnsense.Client, timeout time.Duration) *resource.StateChangeConf {
+	rebooting := false
+
+	return &resource.StateChangeConf{
+		Pending: []string{
+			opnsense.StatusRunning,
+			firmwareStatusNone,
+			firmwareStatusReboot,
+			firmwareStatusRebooting,
+		},
+		Target: []string{
+			opnsense.StatusDone,
+		},
+		Refresh: func() (interface{}, string, error) {
+			resp, err := client.FirmwareUpgradeStatus()
+			if err != nil {
+				if !isConnectionError(err) {
+					return 0, "", err
+				}
+
+				// The API is gone while OPNsense reboots
+				log.Printf("[DEBUG] Waiting for OPNsense to come back: %s", err)
+
+				rebooting = true
+
+				return 0, firmwareStatusRebooting, nil
+			}
+
+			// The progress log lives in /tmp and is cleared by the reboot
+			if rebooting && resp.Status == firmwareStatusNone {
+				return resp, opnsense.StatusDone, nil
+			}
+
+			return resp, resp.Status, nil
+		},
+		Timeout: timeout,
+
+		Delay:                     30 * time.Second,
+		MinTimeout:                10 * time.Second,
+		ContinuousTargetOccurence: 2,
+	}
+}
+
+// isConnectionError reports whether err means OPNsense could not be reached
+// at all, as opposed to an error response from the API.
+func isConnectionError(err error) bool {
+	var netErr net.Error
+
+	return errors.As(err, &netErr) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET)
+}
+
+// compareVersions compares OPNsense versions like 23.7.5_1 part by part.
+func compareVersions(a string, b string) int {
+	split := func(version string) []int {
+		fields := strings.FieldsFunc(version, func(r rune) bool {
+			return r == '.' || r == '_'
+		})
+
+		parts := make([]int, len(fields))
+
+		for index, field := range fields {
+			parts[index], _ = strconv.Atoi(field)
+		}
+
+		return parts
+	}
+
+	partsA := split(a)
+	partsB := split(b)
+
+	for index := 0; index < len(partsA) || index < len(partsB); index++ {
+		var partA, partB int
+
+		if index < len(partsA) {
+			partA = partsA[index]
+		}
+
+		if index < len(partsB) {
+			partB = partsB[index]
+		}
+
+		if partA != partB {
+			if partA < partB {
+				return -1
+			}
+
+			return 1
+		}
+	}
+
+	return 0
+}
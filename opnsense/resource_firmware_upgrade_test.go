@@ -0,0 +1,51 @@
+package opnsense
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a    string
+		b    string
+		want int
+	}{
+		{"23.7.5", "23.7.5", 0},
+		{"23.7.5", "23.7.6", -1},
+		{"23.7.10", "23.7.9", 1},
+		{"24.1", "23.7.12", 1},
+		{"23.7", "23.7.0", 0},
+		{"23.7", "23.7.1", -1},
+		{"23.7.5_1", "23.7.5", 1},
+		{"23.7.5", "23.7.5_2", -1},
+		{"23.7.5_1", "23.7.5_1", 0},
+		{"23.7.5_1", "23.7.6", -1},
+	}
+
+	for _, test := range tests {
+		if got := compareVersions(test.a, test.b); got != test.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", test.a, test.b, got, test.want)
+		}
+	}
+}
+
+func TestIsConnectionError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{&net.OpError{Op: "dial", Err: errors.New("connection refused")}, true},
+		{fmt.Errorf("get status: %w", &net.DNSError{IsTimeout: true}), true},
+		{errors.New("status code 401"), false},
+		{fmt.Errorf("core/firmware/upgradestatus: %w", ErrStatusNotOk), false},
+	}
+
+	for _, test := range tests {
+		if got := isConnectionError(test.err); got != test.want {
+			t.Errorf("isConnectionError(%v) = %t, want %t", test.err, got, test.want)
+		}
+	}
+}
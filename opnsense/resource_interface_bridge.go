@@ -0,0 +1,137 @@
+package opnsense
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type interfaceBridge struct {
+	Members       mvcList   `json:"members"`
+	LinkLocal     mvcBool   `json:"linklocal"`
+	STPEnabled    mvcBool   `json:"enablestp"`
+	STPProtocol   mvcOption `json:"proto"`
+	STPInterfaces mvcList   `json:"stp"`
+	MaxAge        mvcInt    `json:"maxage"`
+	ForwardDelay  mvcInt    `json:"fwdelay"`
+	HoldCount     mvcInt    `json:"holdcnt"`
+	Description   string    `json:"descr"`
+	Device        string    `json:"bridgeif,omitempty"`
+}
+
+var interfaceBridgeItem = &mvcItem{
+	controller:  "interfaces/bridge_settings",
+	name:        "Item",
+	key:         "bridge",
+	reconfigure: mvcReconfigure("interfaces/bridge_settings/reconfigure"),
+
+	newItem: func() interface{} { return &interfaceBridge{} },
+	expand: func(d *schema.ResourceData) (interface{}, error) {
+		return &interfaceBridge{
+			Members:       expandStringSet(d.Get("members").(*schema.Set)),
+			LinkLocal:     mvcBool(d.Get("link_local").(bool)),
+			STPEnabled:    mvcBool(d.Get("stp_enabled").(bool)),
+			STPProtocol:   mvcOption(d.Get("stp_protocol").(string)),
+			STPInterfaces: expandStringSet(d.Get("stp_interfaces").(*schema.Set)),
+			MaxAge:        mvcInt(d.Get("max_age").(int)),
+			ForwardDelay:  mvcInt(d.Get("forward_delay").(int)),
+			HoldCount:     mvcInt(d.Get("hold_count").(int)),
+			Description:   d.Get("description").(string),
+		}, nil
+	},
+	flatten: func(item interface{}) map[string]interface{} {
+		bridge := item.(*interfaceBridge)
+
+		return map[string]interface{}{
+			"members":        []string(bridge.Members),
+			"link_local":     bool(bridge.LinkLocal),
+			"stp_enabled":    bool(bridge.STPEnabled),
+			"stp_protocol":   string(bridge.STPProtocol),
+			"stp_interfaces": []string(bridge.STPInterfaces),
+			"max_age":        int(bridge.MaxAge),
+			"forward_delay":  int(bridge.ForwardDelay),
+			"hold_count":     int(bridge.HoldCount),
+			"description":    bridge.Description,
+			"device":         bridge.Device,
+		}
+	},
+}
+
+func resourceInterfaceBridge() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: interfaceBridgeItem.create,
+		ReadContext:   interfaceBridgeItem.read,
+		UpdateContext: interfaceBridgeItem.update,
+		DeleteContext: interfaceBridgeItem.delete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"members": {
+				Type:        schema.TypeSet,
+				Description: "Member interfaces of the bridge, e.g. lan or opt1",
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"link_local": {
+				Type:        schema.TypeBool,
+				Description: "Enable IPv6 link-local addresses on the bridge",
+				Optional:    true,
+				Default:     false,
+			},
+			"stp_enabled": {
+				Type:        schema.TypeBool,
+				Description: "Enable spanning tree",
+				Optional:    true,
+				Default:     false,
+			},
+			"stp_protocol": {
+				Type:         schema.TypeString,
+				Description:  "Spanning tree protocol, rstp or stp",
+				Optional:     true,
+				Default:      "rstp",
+				ValidateFunc: validation.StringInSlice([]string{"rstp", "stp"}, false),
+			},
+			"stp_interfaces": {
+				Type:        schema.TypeSet,
+				Description: "Members taking part in spanning tree",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"max_age": {
+				Type:         schema.TypeInt,
+				Description:  "Seconds a spanning tree protocol configuration is valid",
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(6, 40),
+			},
+			"forward_delay": {
+				Type:         schema.TypeInt,
+				Description:  "Seconds before forwarding packets on a new port",
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(4, 30),
+			},
+			"hold_count": {
+				Type:         schema.TypeInt,
+				Description:  "Transmit hold count for rapid spanning tree",
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 10),
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "Description of the bridge",
+				Optional:    true,
+			},
+			"device": {
+				Type:        schema.TypeString,
+				Description: "Device name of the bridge, e.g. bridge0",
+				Computed:    true,
+			},
+		},
+	}
+}
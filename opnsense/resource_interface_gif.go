@@ -0,0 +1,83 @@
+package opnsense
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type interfaceGIF struct {
+	LocalAddress        mvcOption `json:"local-addr"`
+	RemoteAddress       string    `json:"remote-addr"`
+	TunnelLocalAddress  string    `json:"tunnel-local-addr"`
+	TunnelRemoteAddress string    `json:"tunnel-remote-addr"`
+	TunnelNetworkBits   mvcInt    `json:"tunnel-remote-net"`
+	IngressFiltering    mvcBool   `json:"ingress-filtering"`
+	ECN                 mvcBool   `json:"ecn"`
+	Description         string    `json:"descr"`
+	Device              string    `json:"gifif,omitempty"`
+}
+
+var interfaceGIFItem = &mvcItem{
+	controller:  "interfaces/gif_settings",
+	name:        "Item",
+	key:         "gif",
+	reconfigure: mvcReconfigure("interfaces/gif_settings/reconfigure"),
+
+	newItem: func() interface{} { return &interfaceGIF{} },
+	expand: func(d *schema.ResourceData) (interface{}, error) {
+		return &interfaceGIF{
+			LocalAddress:        mvcOption(d.Get("local_address").(string)),
+			RemoteAddress:       d.Get("remote_address").(string),
+			TunnelLocalAddress:  d.Get("tunnel_local_address").(string),
+			TunnelRemoteAddress: d.Get("tunnel_remote_address").(string),
+			TunnelNetworkBits:   mvcInt(d.Get("tunnel_network_bits").(int)),
+			IngressFiltering:    mvcBool(d.Get("ingress_filtering").(bool)),
+			ECN:                 mvcBool(d.Get("ecn").(bool)),
+			Description:         d.Get("description").(string),
+		}, nil
+	},
+	flatten: func(item interface{}) map[string]interface{} {
+		gif := item.(*interfaceGIF)
+
+		return map[string]interface{}{
+			"local_address":         string(gif.LocalAddress),
+			"remote_address":        gif.RemoteAddress,
+			"tunnel_local_address":  gif.TunnelLocalAddress,
+			"tunnel_remote_address": gif.TunnelRemoteAddress,
+			"tunnel_network_bits":   int(gif.TunnelNetworkBits),
+			"ingress_filtering":     bool(gif.IngressFiltering),
+			"ecn":                   bool(gif.ECN),
+			"description":           gif.Description,
+			"device":                gif.Device,
+		}
+	},
+}
+
+func resourceInterfaceGIF() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: interfaceGIFItem.create,
+		ReadContext:   interfaceGIFItem.read,
+		UpdateContext: interfaceGIFItem.update,
+		DeleteContext: interfaceGIFItem.delete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		CustomizeDiff: validateTunnelAddresses,
+
+		Schema: tunnelSchema("GIF", map[string]*schema.Schema{
+			"ingress_filtering": {
+				Type:        schema.TypeBool,
+				Description: "Drop packets arriving on the tunnel with a source not routed through it",
+				Optional:    true,
+				Default:     false,
+			},
+			"ecn": {
+				Type:        schema.TypeBool,
+				Description: "Copy explicit congestion notification bits to the outer header",
+				Optional:    true,
+				Default:     false,
+			},
+		}),
+	}
+}
@@ -0,0 +1,145 @@
+package opnsense
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type interfaceGRE struct {
+	LocalAddress        mvcOption `json:"local-addr"`
+	RemoteAddress       string    `json:"remote-addr"`
+	TunnelLocalAddress  string    `json:"tunnel-local-addr"`
+	TunnelRemoteAddress string    `json:"tunnel-remote-addr"`
+	TunnelNetworkBits   mvcInt    `json:"tunnel-remote-net"`
+	Description         string    `json:"descr"`
+	Device              string    `json:"greif,omitempty"`
+}
+
+var interfaceGREItem = &mvcItem{
+	controller:  "interfaces/gre_settings",
+	name:        "Item",
+	key:         "gre",
+	reconfigure: mvcReconfigure("interfaces/gre_settings/reconfigure"),
+
+	newItem: func() interface{} { return &interfaceGRE{} },
+	expand: func(d *schema.ResourceData) (interface{}, error) {
+		return &interfaceGRE{
+			LocalAddress:        mvcOption(d.Get("local_address").(string)),
+			RemoteAddress:       d.Get("remote_address").(string),
+			TunnelLocalAddress:  d.Get("tunnel_local_address").(string),
+			TunnelRemoteAddress: d.Get("tunnel_remote_address").(string),
+			TunnelNetworkBits:   mvcInt(d.Get("tunnel_network_bits").(int)),
+			Description:         d.Get("description").(string),
+		}, nil
+	},
+	flatten: func(item interface{}) map[string]interface{} {
+		gre := item.(*interfaceGRE)
+
+		return map[string]interface{}{
+			"local_address":         string(gre.LocalAddress),
+			"remote_address":        gre.RemoteAddress,
+			"tunnel_local_address":  gre.TunnelLocalAddress,
+			"tunnel_remote_address": gre.TunnelRemoteAddress,
+			"tunnel_network_bits":   int(gre.TunnelNetworkBits),
+			"description":           gre.Description,
+			"device":                gre.Device,
+		}
+	},
+}
+
+func resourceInterfaceGRE() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: interfaceGREItem.create,
+		ReadContext:   interfaceGREItem.read,
+		UpdateContext: interfaceGREItem.update,
+		DeleteContext: interfaceGREItem.delete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		CustomizeDiff: validateTunnelAddresses,
+
+		Schema: tunnelSchema("GRE", map[string]*schema.Schema{}),
+	}
+}
+
+// tunnelSchema returns the schema shared by the GRE and GIF tunnels with
+// extra merged into it.
+func tunnelSchema(kind string, extra map[string]*schema.Schema) map[string]*schema.Schema {
+	tunnel := map[string]*schema.Schema{
+		"local_address": {
+			Type:        schema.TypeString,
+			Description: "Interface or virtual IP the tunnel is sent from, e.g. wan",
+			Required:    true,
+		},
+		"remote_address": {
+			Type:         schema.TypeString,
+			Description:  "Address of the remote tunnel endpoint",
+			Required:     true,
+			ValidateFunc: validation.IsIPAddress,
+		},
+		"tunnel_local_address": {
+			Type:         schema.TypeString,
+			Description:  "Local address inside the tunnel, e.g. 10.0.0.1",
+			Required:     true,
+			ValidateFunc: validation.IsIPAddress,
+		},
+		"tunnel_remote_address": {
+			Type:         schema.TypeString,
+			Description:  "Remote address inside the tunnel, e.g. 10.0.0.2",
+			Required:     true,
+			ValidateFunc: validation.IsIPAddress,
+		},
+		"tunnel_network_bits": {
+			Type:         schema.TypeInt,
+			Description:  "Subnet bits of the tunnel network",
+			Optional:     true,
+			Default:      32,
+			ValidateFunc: validation.IntBetween(1, 128),
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Description: fmt.Sprintf("Description of the %s tunnel", kind),
+			Optional:    true,
+		},
+		"device": {
+			Type:        schema.TypeString,
+			Description: fmt.Sprintf("Device name of the %s tunnel", kind),
+			Computed:    true,
+		},
+	}
+
+	for k, v := range extra {
+		tunnel[k] = v
+	}
+
+	return tunnel
+}
+
+// validateTunnelAddresses checks that both tunnel addresses and the
+// network bits are of the same address family.
+func validateTunnelAddresses(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	local := net.ParseIP(d.Get("tunnel_local_address").(string))
+	remote := net.ParseIP(d.Get("tunnel_remote_address").(string))
+
+	// Unknown until apply, e.g. when referencing another resource
+	if local == nil || remote == nil {
+		return nil
+	}
+
+	if (local.To4() == nil) != (remote.To4() == nil) {
+		return fmt.Errorf("tunnel_local_address %s and tunnel_remote_address %s must be of the same address family",
+			local, remote)
+	}
+
+	if bits := d.Get("tunnel_network_bits").(int); local.To4() != nil && bits > 32 {
+		return fmt.Errorf("tunnel_network_bits must be at most 32 for IPv4 tunnels, got %d", bits)
+	}
+
+	return nil
+}
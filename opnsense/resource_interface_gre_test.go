@@ -0,0 +1,74 @@
+package opnsense
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testInterfaceGREResource(name string, tunnelRemote string) string {
+	return fmt.Sprintf(`
+resource "opnsense_interface_gre" "%s" {
+  local_address         = "wan"
+  remote_address        = "203.0.113.10"
+  tunnel_local_address  = "10.255.0.1"
+  tunnel_remote_address = "%s"
+  tunnel_network_bits   = 30
+  description           = "%s"
+}
+`, name, tunnelRemote, name)
+}
+
+func testAccInterfaceGREResourceDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "opnsense_interface_gre" {
+			continue
+		}
+
+		err := testAccCheckMvcItemDestroyed(interfaceGREItem, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestInterfaceGRE_basic(t *testing.T) {
+	rName := fmt.Sprintf("a%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccInterfaceGREResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testInterfaceGREResource(rName, "fd00::2"),
+				ExpectError: regexp.MustCompile("same address family"),
+			},
+			{
+				Config: testInterfaceGREResource(rName, "10.255.0.2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						fmt.Sprintf("opnsense_interface_gre.%s", rName),
+						"tunnel_network_bits",
+						"30",
+					),
+					resource.TestCheckResourceAttrSet(
+						fmt.Sprintf("opnsense_interface_gre.%s", rName),
+						"device",
+					),
+				),
+			},
+			{
+				ResourceName:      fmt.Sprintf("opnsense_interface_gre.%s", rName),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,122 @@
+package opnsense
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type interfaceLAGG struct {
+	Members         mvcList   `json:"members"`
+	PrimaryMember   mvcOption `json:"primary_member"`
+	Protocol        mvcOption `json:"proto"`
+	LACPFastTimeout mvcBool   `json:"lacp_fast_timeout"`
+	Hash            mvcList   `json:"lagghash"`
+	MTU             mvcInt    `json:"mtu"`
+	Description     string    `json:"descr"`
+	Device          string    `json:"laggif,omitempty"`
+}
+
+var interfaceLAGGItem = &mvcItem{
+	controller:  "interfaces/lagg_settings",
+	name:        "Item",
+	key:         "lagg",
+	reconfigure: mvcReconfigure("interfaces/lagg_settings/reconfigure"),
+
+	newItem: func() interface{} { return &interfaceLAGG{} },
+	expand: func(d *schema.ResourceData) (interface{}, error) {
+		return &interfaceLAGG{
+			Members:         expandStringSet(d.Get("members").(*schema.Set)),
+			PrimaryMember:   mvcOption(d.Get("primary_member").(string)),
+			Protocol:        mvcOption(d.Get("protocol").(string)),
+			LACPFastTimeout: mvcBool(d.Get("lacp_fast_timeout").(bool)),
+			Hash:            expandStringSet(d.Get("hash").(*schema.Set)),
+			MTU:             mvcInt(d.Get("mtu").(int)),
+			Description:     d.Get("description").(string),
+		}, nil
+	},
+	flatten: func(item interface{}) map[string]interface{} {
+		lagg := item.(*interfaceLAGG)
+
+		return map[string]interface{}{
+			"members":           []string(lagg.Members),
+			"primary_member":    string(lagg.PrimaryMember),
+			"protocol":          string(lagg.Protocol),
+			"lacp_fast_timeout": bool(lagg.LACPFastTimeout),
+			"hash":              []string(lagg.Hash),
+			"mtu":               int(lagg.MTU),
+			"description":       lagg.Description,
+			"device":            lagg.Device,
+		}
+	},
+}
+
+func resourceInterfaceLAGG() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: interfaceLAGGItem.create,
+		ReadContext:   interfaceLAGGItem.read,
+		UpdateContext: interfaceLAGGItem.update,
+		DeleteContext: interfaceLAGGItem.delete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"members": {
+				Type:        schema.TypeSet,
+				Description: "Member devices of the LAGG, e.g. igb0",
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"primary_member": {
+				Type:        schema.TypeString,
+				Description: "Member used as primary interface, defaults to the first member",
+				Optional:    true,
+			},
+			"protocol": {
+				Type:        schema.TypeString,
+				Description: "Aggregation protocol",
+				Optional:    true,
+				Default:     "lacp",
+				ValidateFunc: validation.StringInSlice([]string{
+					"none", "lacp", "failover", "fec", "loadbalance", "roundrobin",
+				}, false),
+			},
+			"lacp_fast_timeout": {
+				Type:        schema.TypeBool,
+				Description: "Send LACP packets every second instead of every 30 seconds",
+				Optional:    true,
+				Default:     false,
+			},
+			"hash": {
+				Type:        schema.TypeSet,
+				Description: "Header layers used for the lacp and loadbalance hash, l2, l3 and l4",
+				Optional:    true,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{"l2", "l3", "l4"}, false),
+				},
+			},
+			"mtu": {
+				Type:         schema.TypeInt,
+				Description:  "MTU of the LAGG, leave empty to use the member MTU",
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(576, 65535),
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "Description of the LAGG",
+				Optional:    true,
+			},
+			"device": {
+				Type:        schema.TypeString,
+				Description: "Device name of the LAGG, e.g. lagg0",
+				Computed:    true,
+			},
+		},
+	}
+}
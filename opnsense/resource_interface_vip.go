@@ -0,0 +1,224 @@
+package opnsense
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+const vipModeCARP = "carp"
+
+type interfaceVIP struct {
+	Interface   mvcOption `json:"interface"`
+	Mode        mvcOption `json:"mode"`
+	Subnet      string    `json:"subnet"`
+	SubnetBits  mvcInt    `json:"subnet_bits"`
+	Gateway     string    `json:"gateway"`
+	NoExpand    mvcBool   `json:"noexpand"`
+	NoBind      mvcBool   `json:"nobind"`
+	Password    string    `json:"password"`
+	VHID        mvcInt    `json:"vhid"`
+	AdvBase     mvcInt    `json:"advbase"`
+	AdvSkew     mvcNumber `json:"advskew"`
+	Description string    `json:"descr"`
+}
+
+type interfaceVIPRow struct {
+	UUID string `json:"uuid"`
+	VHID mvcInt `json:"vhid"`
+}
+
+var interfaceVIPItem = &mvcItem{
+	controller:  "interfaces/vip_settings",
+	name:        "Item",
+	key:         "vip",
+	reconfigure: mvcReconfigure("interfaces/vip_settings/reconfigure"),
+
+	newItem: func() interface{} { return &interfaceVIP{} },
+	expand: func(d *schema.ResourceData) (interface{}, error) {
+		ip, ipNet, err := net.ParseCIDR(d.Get("address").(string))
+		if err != nil {
+			return nil, err
+		}
+
+		bits, _ := ipNet.Mask.Size()
+
+		return &interfaceVIP{
+			Interface:   mvcOption(d.Get("interface").(string)),
+			Mode:        mvcOption(d.Get("mode").(string)),
+			Subnet:      ip.String(),
+			SubnetBits:  mvcInt(bits),
+			Gateway:     d.Get("gateway").(string),
+			NoExpand:    mvcBool(d.Get("no_expand").(bool)),
+			NoBind:      mvcBool(d.Get("no_bind").(bool)),
+			Password:    d.Get("password").(string),
+			VHID:        mvcInt(d.Get("vhid").(int)),
+			AdvBase:     mvcInt(d.Get("advbase").(int)),
+			AdvSkew:     mvcNumber(d.Get("advskew").(int)),
+			Description: d.Get("description").(string),
+		}, nil
+	},
+	flatten: func(item interface{}) map[string]interface{} {
+		vip := item.(*interfaceVIP)
+
+		return map[string]interface{}{
+			"interface":   string(vip.Interface),
+			"mode":        string(vip.Mode),
+			"address":     fmt.Sprintf("%s/%d", vip.Subnet, vip.SubnetBits),
+			"gateway":     vip.Gateway,
+			"no_expand":   bool(vip.NoExpand),
+			"no_bind":     bool(vip.NoBind),
+			"password":    vip.Password,
+			"vhid":        int(vip.VHID),
+			"advbase":     int(vip.AdvBase),
+			"advskew":     int(vip.AdvSkew),
+			"description": vip.Description,
+		}
+	},
+}
+
+func resourceInterfaceVIP() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: interfaceVIPItem.create,
+		ReadContext:   interfaceVIPItem.read,
+		UpdateContext: interfaceVIPItem.update,
+		DeleteContext: interfaceVIPItem.delete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		CustomizeDiff: resourceInterfaceVIPCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"interface": {
+				Type:        schema.TypeString,
+				Description: "Interface the virtual IP is added to, e.g. lan",
+				Required:    true,
+			},
+			"mode": {
+				Type:         schema.TypeString,
+				Description:  "Type of virtual IP, ipalias, carp, proxyarp or other",
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"ipalias", vipModeCARP, "proxyarp", "other"}, false),
+			},
+			"address": {
+				Type:         schema.TypeString,
+				Description:  "Address with subnet bits, e.g. 192.0.2.10/24",
+				Required:     true,
+				ValidateFunc: validation.IsCIDR,
+			},
+			"gateway": {
+				Type:         schema.TypeString,
+				Description:  "Gateway for addresses outside of the interface network",
+				Optional:     true,
+				ValidateFunc: validation.Any(validation.StringIsEmpty, validation.IsIPAddress),
+			},
+			"no_expand": {
+				Type:        schema.TypeBool,
+				Description: "Disable expansion of the subnet into separate addresses, proxyarp and other only",
+				Optional:    true,
+				Default:     false,
+			},
+			"no_bind": {
+				Type:        schema.TypeBool,
+				Description: "Prevent services from binding to the address",
+				Optional:    true,
+				Default:     false,
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Description: "CARP password shared with the other members of the VHID group",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"vhid": {
+				Type: schema.TypeInt,
+				Description: "Virtual host ID, unique per interface for CARP. Only checked against the " +
+					"addresses already on OPNsense, not against other addresses in the same plan",
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 255),
+			},
+			"advbase": {
+				Type:         schema.TypeInt,
+				Description:  "Base advertisement frequency in seconds",
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntBetween(1, 254),
+			},
+			"advskew": {
+				Type:         schema.TypeInt,
+				Description:  "Advertisement skew, the member with the lowest skew is master",
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntBetween(0, 254),
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "Description of the virtual IP",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// resourceInterfaceVIPCustomizeDiff checks the CARP settings and that the
+// VHID is not used by another CARP address on the same interface.
+func resourceInterfaceVIPCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Get("mode").(string) != vipModeCARP {
+		return nil
+	}
+
+	vhid := d.Get("vhid").(int)
+	iface := d.Get("interface").(string)
+
+	if d.NewValueKnown("vhid") && vhid == 0 {
+		return fmt.Errorf("vhid is required for CARP addresses")
+	}
+
+	if d.NewValueKnown("password") && d.Get("password").(string) == "" {
+		return fmt.Errorf("password is required for CARP addresses")
+	}
+
+	if meta == nil || vhid == 0 || !d.NewValueKnown("vhid") || !d.NewValueKnown("interface") {
+		return nil
+	}
+
+	m := meta.(*providerMeta)
+
+	rows := []interfaceVIPRow{}
+
+	err := interfaceVIPItem.search(m, &rows)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		if int(row.VHID) != vhid || row.UUID == d.Id() {
+			continue
+		}
+
+		id, err := uuid.FromString(row.UUID)
+		if err != nil {
+			return err
+		}
+
+		item, err := interfaceVIPItem.get(m, id)
+		if err != nil {
+			return err
+		}
+
+		other := item.(*interfaceVIP)
+
+		if string(other.Mode) == vipModeCARP && string(other.Interface) == iface {
+			return fmt.Errorf("vhid %d is already used on interface %s by CARP address %s/%d",
+				vhid, iface, other.Subnet, other.SubnetBits)
+		}
+	}
+
+	return nil
+}
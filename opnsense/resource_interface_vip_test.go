@@ -0,0 +1,83 @@
+package opnsense
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testInterfaceVIPResource(name string) string {
+	return fmt.Sprintf(`
+resource "opnsense_interface_vip" "%s" {
+  interface   = "lan"
+  mode        = "carp"
+  address     = "192.168.1.250/24"
+  vhid        = 42
+  password    = "carp-secret"
+  advskew     = 100
+  description = "%s"
+}
+`, name, name)
+}
+
+func testInterfaceVIPDuplicateResource(name string) string {
+	return testInterfaceVIPResource(name) + fmt.Sprintf(`
+resource "opnsense_interface_vip" "%s_duplicate" {
+  interface = "lan"
+  mode      = "carp"
+  address   = "192.168.1.251/24"
+  vhid      = opnsense_interface_vip.%s.vhid
+  password  = "carp-secret"
+}
+`, name, name)
+}
+
+func testAccInterfaceVIPResourceDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "opnsense_interface_vip" {
+			continue
+		}
+
+		err := testAccCheckMvcItemDestroyed(interfaceVIPItem, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestInterfaceVIP_carp(t *testing.T) {
+	rName := fmt.Sprintf("a%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccInterfaceVIPResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testInterfaceVIPResource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						fmt.Sprintf("opnsense_interface_vip.%s", rName),
+						"vhid",
+						"42",
+					),
+					resource.TestCheckResourceAttr(
+						fmt.Sprintf("opnsense_interface_vip.%s", rName),
+						"address",
+						"192.168.1.250/24",
+					),
+				),
+			},
+			{
+				Config:      testInterfaceVIPDuplicateResource(rName),
+				ExpectError: regexp.MustCompile("vhid 42 is already used"),
+			},
+		},
+	})
+}
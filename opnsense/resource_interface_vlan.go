@@ -0,0 +1,95 @@
+package opnsense
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type interfaceVLAN struct {
+	Parent      mvcOption `json:"if"`
+	Tag         mvcInt    `json:"tag"`
+	PCP         mvcOption `json:"pcp"`
+	Protocol    mvcOption `json:"proto"`
+	Description string    `json:"descr"`
+	Device      string    `json:"vlanif,omitempty"`
+}
+
+var interfaceVLANItem = &mvcItem{
+	controller:  "interfaces/vlan_settings",
+	name:        "Item",
+	key:         "vlan",
+	reconfigure: mvcReconfigure("interfaces/vlan_settings/reconfigure"),
+
+	newItem: func() interface{} { return &interfaceVLAN{} },
+	expand: func(d *schema.ResourceData) (interface{}, error) {
+		return &interfaceVLAN{
+			Parent:      mvcOption(d.Get("parent").(string)),
+			Tag:         mvcInt(d.Get("tag").(int)),
+			PCP:         mvcOption(d.Get("pcp").(string)),
+			Protocol:    mvcOption(d.Get("protocol").(string)),
+			Description: d.Get("description").(string),
+		}, nil
+	},
+	flatten: func(item interface{}) map[string]interface{} {
+		vlan := item.(*interfaceVLAN)
+
+		return map[string]interface{}{
+			"parent":      string(vlan.Parent),
+			"tag":         int(vlan.Tag),
+			"pcp":         string(vlan.PCP),
+			"protocol":    string(vlan.Protocol),
+			"description": vlan.Description,
+			"device":      vlan.Device,
+		}
+	},
+}
+
+func resourceInterfaceVLAN() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: interfaceVLANItem.create,
+		ReadContext:   interfaceVLANItem.read,
+		UpdateContext: interfaceVLANItem.update,
+		DeleteContext: interfaceVLANItem.delete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"parent": {
+				Type:        schema.TypeString,
+				Description: "Parent device of the VLAN, e.g. igb0 or lagg0",
+				Required:    true,
+			},
+			"tag": {
+				Type:         schema.TypeInt,
+				Description:  "VLAN tag",
+				Required:     true,
+				ValidateFunc: validation.IntBetween(1, 4094),
+			},
+			"pcp": {
+				Type:         schema.TypeString,
+				Description:  "802.1p priority code point",
+				Optional:     true,
+				Default:      "0",
+				ValidateFunc: validation.StringInSlice([]string{"0", "1", "2", "3", "4", "5", "6", "7"}, false),
+			},
+			"protocol": {
+				Type:         schema.TypeString,
+				Description:  "VLAN protocol, empty for the default, 802.1q or 802.1ad",
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"", "802.1q", "802.1ad"}, false),
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "Description of the VLAN",
+				Optional:    true,
+			},
+			"device": {
+				Type:        schema.TypeString,
+				Description: "Device name of the VLAN, e.g. vlan01",
+				Computed:    true,
+			},
+		},
+	}
+}
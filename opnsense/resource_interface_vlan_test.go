@@ -0,0 +1,75 @@
+package opnsense
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testInterfaceVLANResource(name string, tag int) string {
+	return fmt.Sprintf(`
+data "opnsense_interface" "%s" {
+  identifier = "lan"
+}
+
+resource "opnsense_interface_vlan" "%s" {
+  parent      = data.opnsense_interface.%s.device
+  tag         = %d
+  description = "%s"
+}
+`, name, name, name, tag, name)
+}
+
+func testAccInterfaceVLANResourceDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "opnsense_interface_vlan" {
+			continue
+		}
+
+		err := testAccCheckMvcItemDestroyed(interfaceVLANItem, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestInterfaceVLAN_basic(t *testing.T) {
+	rName := fmt.Sprintf("a%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccInterfaceVLANResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testInterfaceVLANResource(rName, 100),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						fmt.Sprintf("opnsense_interface_vlan.%s", rName),
+						"tag",
+						"100",
+					),
+					resource.TestCheckResourceAttrSet(
+						fmt.Sprintf("opnsense_interface_vlan.%s", rName),
+						"device",
+					),
+				),
+			},
+			{
+				Config: testInterfaceVLANResource(rName, 101),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						fmt.Sprintf("opnsense_interface_vlan.%s", rName),
+						"tag",
+						"101",
+					),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,140 @@
+package opnsense
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type interfaceVXLAN struct {
+	DeviceID        mvcInt    `json:"deviceId"`
+	VNI             mvcInt    `json:"vxlanid"`
+	LocalAddress    string    `json:"vxlanlocal"`
+	RemoteAddress   string    `json:"vxlanremote"`
+	Group           string    `json:"vxlangroup"`
+	MulticastDevice mvcOption `json:"vxlandev"`
+}
+
+var interfaceVXLANItem = &mvcItem{
+	controller:  "interfaces/vxlan_settings",
+	name:        "Item",
+	key:         "vxlan",
+	reconfigure: mvcReconfigure("interfaces/vxlan_settings/reconfigure"),
+
+	newItem: func() interface{} { return &interfaceVXLAN{} },
+	expand: func(d *schema.ResourceData) (interface{}, error) {
+		return &interfaceVXLAN{
+			DeviceID:        mvcInt(d.Get("device_id").(int)),
+			VNI:             mvcInt(d.Get("vni").(int)),
+			LocalAddress:    d.Get("local_address").(string),
+			RemoteAddress:   d.Get("remote_address").(string),
+			Group:           d.Get("group").(string),
+			MulticastDevice: mvcOption(d.Get("multicast_device").(string)),
+		}, nil
+	},
+	flatten: func(item interface{}) map[string]interface{} {
+		vxlan := item.(*interfaceVXLAN)
+
+		return map[string]interface{}{
+			"device_id":        int(vxlan.DeviceID),
+			"vni":              int(vxlan.VNI),
+			"local_address":    vxlan.LocalAddress,
+			"remote_address":   vxlan.RemoteAddress,
+			"group":            vxlan.Group,
+			"multicast_device": string(vxlan.MulticastDevice),
+			"device":           fmt.Sprintf("vxlan%d", vxlan.DeviceID),
+		}
+	},
+}
+
+func resourceInterfaceVXLAN() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: interfaceVXLANItem.create,
+		ReadContext:   interfaceVXLANItem.read,
+		UpdateContext: interfaceVXLANItem.update,
+		DeleteContext: interfaceVXLANItem.delete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		CustomizeDiff: resourceInterfaceVXLANCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"device_id": {
+				Type:         schema.TypeInt,
+				Description:  "Number of the vxlan device, the next free number including 0 is used when empty",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"vni": {
+				Type:         schema.TypeInt,
+				Description:  "VXLAN network identifier",
+				Required:     true,
+				ValidateFunc: validation.IntBetween(1, 16777215),
+			},
+			"local_address": {
+				Type:         schema.TypeString,
+				Description:  "Source address of the encapsulated packets",
+				Required:     true,
+				ValidateFunc: validation.IsIPAddress,
+			},
+			"remote_address": {
+				Type:         schema.TypeString,
+				Description:  "Address of the remote endpoint for unicast VXLAN",
+				Optional:     true,
+				ValidateFunc: validation.IsIPAddress,
+				ExactlyOneOf: []string{"remote_address", "group"},
+			},
+			"group": {
+				Type:         schema.TypeString,
+				Description:  "Multicast group address for multicast VXLAN",
+				Optional:     true,
+				ValidateFunc: validation.IsIPAddress,
+				ExactlyOneOf: []string{"remote_address", "group"},
+				RequiredWith: []string{"multicast_device"},
+			},
+			"multicast_device": {
+				Type:         schema.TypeString,
+				Description:  "Device to send multicast traffic on, required with group",
+				Optional:     true,
+				RequiredWith: []string{"group"},
+			},
+			"device": {
+				Type:        schema.TypeString,
+				Description: "Device name of the VXLAN, e.g. vxlan0",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceInterfaceVXLANCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	local := net.ParseIP(d.Get("local_address").(string))
+
+	peerKey := "remote_address"
+	if d.Get("group").(string) != "" {
+		peerKey = "group"
+	}
+
+	peer := net.ParseIP(d.Get(peerKey).(string))
+
+	// Unknown until apply, e.g. when referencing another resource
+	if local == nil || peer == nil {
+		return nil
+	}
+
+	if (local.To4() == nil) != (peer.To4() == nil) {
+		return fmt.Errorf("local_address %s and %s %s must be of the same address family", local, peerKey, peer)
+	}
+
+	if peerKey == "group" && !peer.IsMulticast() {
+		return fmt.Errorf("group %s is not a multicast address", peer)
+	}
+
+	return nil
+}
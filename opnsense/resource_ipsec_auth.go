@@ -0,0 +1,170 @@
+package opnsense
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type ipsecAuth struct {
+	Enabled     mvcBool   `json:"enabled"`
+	Connection  mvcOption `json:"connection"`
+	Round       mvcInt    `json:"round"`
+	Auth        mvcOption `json:"auth"`
+	ID          string    `json:"id"`
+	EAPID       string    `json:"eap_id"`
+	Certs       mvcList   `json:"certs"`
+	CACerts     mvcList   `json:"cacerts,omitempty"`
+	Description string    `json:"description"`
+}
+
+var ipsecLocalAuthItem = newIPsecAuthItem("Local", "local", false)
+
+var ipsecRemoteAuthItem = newIPsecAuthItem("Remote", "remote", true)
+
+// newIPsecAuthItem returns the item for local or remote authentication,
+// which only differ in the CA certificates remote peers are checked against.
+func newIPsecAuthItem(name string, key string, remote bool) *mvcItem {
+	return &mvcItem{
+		controller:  "ipsec/connections",
+		name:        name,
+		key:         key,
+		reconfigure: ipsecReconfigure,
+
+		newItem: func() interface{} { return &ipsecAuth{} },
+		expand: func(d *schema.ResourceData) (interface{}, error) {
+			auth := &ipsecAuth{
+				Enabled:     mvcBool(d.Get("enabled").(bool)),
+				Connection:  mvcOption(d.Get("connection_id").(string)),
+				Round:       mvcInt(d.Get("round").(int)),
+				Auth:        mvcOption(d.Get("auth").(string)),
+				ID:          d.Get("identity").(string),
+				EAPID:       d.Get("eap_id").(string),
+				Certs:       expandStringSet(d.Get("certificates").(*schema.Set)),
+				Description: d.Get("description").(string),
+			}
+
+			if remote {
+				auth.CACerts = expandStringSet(d.Get("ca_certificates").(*schema.Set))
+			}
+
+			return auth, nil
+		},
+		flatten: func(item interface{}) map[string]interface{} {
+			auth := item.(*ipsecAuth)
+
+			values := map[string]interface{}{
+				"enabled":       bool(auth.Enabled),
+				"connection_id": string(auth.Connection),
+				"round":         int(auth.Round),
+				"auth":          string(auth.Auth),
+				"identity":      auth.ID,
+				"eap_id":        auth.EAPID,
+				"certificates":  []string(auth.Certs),
+				"description":   auth.Description,
+			}
+
+			if remote {
+				values["ca_certificates"] = []string(auth.CACerts)
+			}
+
+			return values
+		},
+	}
+}
+
+func ipsecAuthSchema(remote bool) map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"enabled": {
+			Type:        schema.TypeBool,
+			Description: "Enable the authentication round",
+			Optional:    true,
+			Default:     true,
+		},
+		"connection_id": {
+			Type:         schema.TypeString,
+			Description:  "ID of the opnsense_ipsec_connection",
+			Required:     true,
+			ValidateFunc: validation.IsUUID,
+		},
+		"round": {
+			Type:         schema.TypeInt,
+			Description:  "Authentication round, only needed for multiple authentication rounds",
+			Optional:     true,
+			ValidateFunc: validation.IntBetween(0, 10),
+		},
+		"auth": {
+			Type:        schema.TypeString,
+			Description: "Authentication method",
+			Required:    true,
+			ValidateFunc: validation.StringInSlice([]string{
+				"psk", "pubkey", "eap-tls", "eap-mschapv2", "xauth-pam", "eap-radius",
+			}, false),
+		},
+		"identity": {
+			Type:        schema.TypeString,
+			Description: "IKE identity, e.g. an address, FQDN or email, matched against opnsense_ipsec_psk",
+			Optional:    true,
+		},
+		"eap_id": {
+			Type:        schema.TypeString,
+			Description: "Identity for EAP authentication",
+			Optional:    true,
+		},
+		"certificates": {
+			Type:        schema.TypeSet,
+			Description: "Reference IDs of the certificates used for pubkey authentication",
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Description: "Description of the authentication round",
+			Optional:    true,
+		},
+	}
+
+	if remote {
+		s["ca_certificates"] = &schema.Schema{
+			Type:        schema.TypeSet,
+			Description: "Reference IDs of the CAs the certificate of the peer must be issued by",
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		}
+	}
+
+	return s
+}
+
+func resourceIPsecLocalAuth() *schema.Resource {
+	return &schema.Resource{
+		Description: "How OPNsense authenticates itself to the peer of an opnsense_ipsec_connection.",
+
+		CreateContext: ipsecLocalAuthItem.create,
+		ReadContext:   ipsecLocalAuthItem.read,
+		UpdateContext: ipsecLocalAuthItem.update,
+		DeleteContext: ipsecLocalAuthItem.delete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: ipsecAuthSchema(false),
+	}
+}
+
+func resourceIPsecRemoteAuth() *schema.Resource {
+	return &schema.Resource{
+		Description: "How the peer of an opnsense_ipsec_connection has to authenticate itself.",
+
+		CreateContext: ipsecRemoteAuthItem.create,
+		ReadContext:   ipsecRemoteAuthItem.read,
+		UpdateContext: ipsecRemoteAuthItem.update,
+		DeleteContext: ipsecRemoteAuthItem.delete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: ipsecAuthSchema(true),
+	}
+}
@@ -0,0 +1,156 @@
+package opnsense
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type ipsecChild struct {
+	Enabled      mvcBool   `json:"enabled"`
+	Connection   mvcOption `json:"connection"`
+	ESPProposals mvcList   `json:"esp_proposals"`
+	StartAction  mvcOption `json:"start_action"`
+	DPDAction    mvcOption `json:"dpd_action"`
+	Mode         mvcOption `json:"mode"`
+	Policies     mvcBool   `json:"policies"`
+	LocalTS      mvcList   `json:"local_ts"`
+	RemoteTS     mvcList   `json:"remote_ts"`
+	RekeyTime    mvcInt    `json:"rekey_time"`
+	Description  string    `json:"description"`
+}
+
+var ipsecChildItem = &mvcItem{
+	controller:  "ipsec/connections",
+	name:        "Child",
+	key:         "child",
+	reconfigure: ipsecReconfigure,
+
+	newItem: func() interface{} { return &ipsecChild{} },
+	expand: func(d *schema.ResourceData) (interface{}, error) {
+		return &ipsecChild{
+			Enabled:      mvcBool(d.Get("enabled").(bool)),
+			Connection:   mvcOption(d.Get("connection_id").(string)),
+			ESPProposals: expandStringSet(d.Get("esp_proposals").(*schema.Set)),
+			StartAction:  mvcOption(d.Get("start_action").(string)),
+			DPDAction:    mvcOption(d.Get("dpd_action").(string)),
+			Mode:         mvcOption(d.Get("mode").(string)),
+			Policies:     mvcBool(d.Get("policies").(bool)),
+			LocalTS:      expandStringSet(d.Get("local_traffic_selectors").(*schema.Set)),
+			RemoteTS:     expandStringSet(d.Get("remote_traffic_selectors").(*schema.Set)),
+			RekeyTime:    mvcInt(d.Get("rekey_time").(int)),
+			Description:  d.Get("description").(string),
+		}, nil
+	},
+	flatten: func(item interface{}) map[string]interface{} {
+		child := item.(*ipsecChild)
+
+		return map[string]interface{}{
+			"enabled":                  bool(child.Enabled),
+			"connection_id":            string(child.Connection),
+			"esp_proposals":            []string(child.ESPProposals),
+			"start_action":             string(child.StartAction),
+			"dpd_action":               string(child.DPDAction),
+			"mode":                     string(child.Mode),
+			"policies":                 bool(child.Policies),
+			"local_traffic_selectors":  []string(child.LocalTS),
+			"remote_traffic_selectors": []string(child.RemoteTS),
+			"rekey_time":               int(child.RekeyTime),
+			"description":              child.Description,
+		}
+	},
+}
+
+func resourceIPsecChild() *schema.Resource {
+	return &schema.Resource{
+		Description: "A child SA of an opnsense_ipsec_connection, selecting the traffic sent through the tunnel.",
+
+		CreateContext: ipsecChildItem.create,
+		ReadContext:   ipsecChildItem.read,
+		UpdateContext: ipsecChildItem.update,
+		DeleteContext: ipsecChildItem.delete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Description: "Enable the child SA",
+				Optional:    true,
+				Default:     true,
+			},
+			"connection_id": {
+				Type:         schema.TypeString,
+				Description:  "ID of the opnsense_ipsec_connection",
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"esp_proposals": {
+				Type:        schema.TypeSet,
+				Description: "ESP proposals offered, e.g. aes256gcm16-modp2048",
+				Optional:    true,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"start_action": {
+				Type:         schema.TypeString,
+				Description:  "Action after loading the configuration, one of none, trap, start or trap|start",
+				Optional:     true,
+				Default:      "start",
+				ValidateFunc: validation.StringInSlice([]string{"none", "trap", "start", "trap|start"}, false),
+			},
+			"dpd_action": {
+				Type:         schema.TypeString,
+				Description:  "Action when the peer is detected dead, one of clear, trap or start",
+				Optional:     true,
+				Default:      "clear",
+				ValidateFunc: validation.StringInSlice([]string{"clear", "trap", "start"}, false),
+			},
+			"mode": {
+				Type:         schema.TypeString,
+				Description:  "IPsec mode, one of tunnel, transport, pass or drop",
+				Optional:     true,
+				Default:      "tunnel",
+				ValidateFunc: validation.StringInSlice([]string{"tunnel", "transport", "pass", "drop"}, false),
+			},
+			"policies": {
+				Type:        schema.TypeBool,
+				Description: "Install IPsec policies, disable for route based tunnels",
+				Optional:    true,
+				Default:     true,
+			},
+			"local_traffic_selectors": {
+				Type:        schema.TypeSet,
+				Description: "Local networks sent through the tunnel",
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsCIDRNetwork(0, 128),
+				},
+			},
+			"remote_traffic_selectors": {
+				Type:        schema.TypeSet,
+				Description: "Remote networks reached through the tunnel",
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsCIDRNetwork(0, 128),
+				},
+			},
+			"rekey_time": {
+				Type:         schema.TypeInt,
+				Description:  "Seconds between child SA rekeys, empty uses the strongSwan default",
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "Description of the child SA",
+				Optional:    true,
+			},
+		},
+	}
+}
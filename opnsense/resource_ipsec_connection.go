@@ -0,0 +1,169 @@
+package opnsense
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type ipsecConnection struct {
+	Enabled     mvcBool   `json:"enabled"`
+	Proposals   mvcList   `json:"proposals"`
+	Unique      mvcOption `json:"unique"`
+	Aggressive  mvcBool   `json:"aggressive"`
+	Version     mvcOption `json:"version"`
+	Mobike      mvcBool   `json:"mobike"`
+	LocalAddrs  mvcList   `json:"local_addrs"`
+	RemoteAddrs mvcList   `json:"remote_addrs"`
+	Encap       mvcBool   `json:"encap"`
+	RekeyTime   mvcInt    `json:"rekey_time"`
+	DPDDelay    mvcInt    `json:"dpd_delay"`
+	DPDTimeout  mvcInt    `json:"dpd_timeout"`
+	Description string    `json:"description"`
+}
+
+var ipsecReconfigure = mvcReconfigure("ipsec/service/reconfigure")
+
+var ipsecConnectionItem = &mvcItem{
+	controller:  "ipsec/connections",
+	name:        "Connection",
+	key:         "connection",
+	reconfigure: ipsecReconfigure,
+
+	newItem: func() interface{} { return &ipsecConnection{} },
+	expand: func(d *schema.ResourceData) (interface{}, error) {
+		return &ipsecConnection{
+			Enabled:     mvcBool(d.Get("enabled").(bool)),
+			Proposals:   expandStringSet(d.Get("proposals").(*schema.Set)),
+			Unique:      mvcOption(d.Get("unique").(string)),
+			Aggressive:  mvcBool(d.Get("aggressive").(bool)),
+			Version:     mvcOption(d.Get("version").(string)),
+			Mobike:      mvcBool(d.Get("mobike").(bool)),
+			LocalAddrs:  expandStringSet(d.Get("local_addresses").(*schema.Set)),
+			RemoteAddrs: expandStringSet(d.Get("remote_addresses").(*schema.Set)),
+			Encap:       mvcBool(d.Get("udp_encapsulation").(bool)),
+			RekeyTime:   mvcInt(d.Get("rekey_time").(int)),
+			DPDDelay:    mvcInt(d.Get("dpd_delay").(int)),
+			DPDTimeout:  mvcInt(d.Get("dpd_timeout").(int)),
+			Description: d.Get("description").(string),
+		}, nil
+	},
+	flatten: func(item interface{}) map[string]interface{} {
+		connection := item.(*ipsecConnection)
+
+		return map[string]interface{}{
+			"enabled":           bool(connection.Enabled),
+			"proposals":         []string(connection.Proposals),
+			"unique":            string(connection.Unique),
+			"aggressive":        bool(connection.Aggressive),
+			"version":           string(connection.Version),
+			"mobike":            bool(connection.Mobike),
+			"local_addresses":   []string(connection.LocalAddrs),
+			"remote_addresses":  []string(connection.RemoteAddrs),
+			"udp_encapsulation": bool(connection.Encap),
+			"rekey_time":        int(connection.RekeyTime),
+			"dpd_delay":         int(connection.DPDDelay),
+			"dpd_timeout":       int(connection.DPDTimeout),
+			"description":       connection.Description,
+		}
+	},
+}
+
+func resourceIPsecConnection() *schema.Resource {
+	return &schema.Resource{
+		Description: "An IKE connection of the swanctl based IPsec configuration. Authentication " +
+			"and traffic selectors are added with opnsense_ipsec_local_auth, " +
+			"opnsense_ipsec_remote_auth and opnsense_ipsec_child.",
+
+		CreateContext: ipsecConnectionItem.create,
+		ReadContext:   ipsecConnectionItem.read,
+		UpdateContext: ipsecConnectionItem.update,
+		DeleteContext: ipsecConnectionItem.delete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Description: "Enable the connection",
+				Optional:    true,
+				Default:     true,
+			},
+			"proposals": {
+				Type:        schema.TypeSet,
+				Description: "IKE proposals offered, e.g. aes256-sha256-modp2048",
+				Optional:    true,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"unique": {
+				Type:         schema.TypeString,
+				Description:  "Uniqueness policy of the IKE SA, one of no, never, keep or replace",
+				Optional:     true,
+				Default:      "no",
+				ValidateFunc: validation.StringInSlice([]string{"no", "never", "keep", "replace"}, false),
+			},
+			"aggressive": {
+				Type:        schema.TypeBool,
+				Description: "Use IKEv1 aggressive mode",
+				Optional:    true,
+				Default:     false,
+			},
+			"version": {
+				Type:         schema.TypeString,
+				Description:  "IKE version, 0 for any, 1 for IKEv1 or 2 for IKEv2",
+				Optional:     true,
+				Default:      "2",
+				ValidateFunc: validation.StringInSlice([]string{"0", "1", "2"}, false),
+			},
+			"mobike": {
+				Type:        schema.TypeBool,
+				Description: "Enable MOBIKE for IKEv2",
+				Optional:    true,
+				Default:     true,
+			},
+			"local_addresses": {
+				Type:        schema.TypeSet,
+				Description: "Local addresses or host names to use for IKE, empty uses any",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"remote_addresses": {
+				Type:        schema.TypeSet,
+				Description: "Remote addresses or host names of the peer, empty accepts any",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"udp_encapsulation": {
+				Type:        schema.TypeBool,
+				Description: "Force UDP encapsulation of ESP packets",
+				Optional:    true,
+				Default:     false,
+			},
+			"rekey_time": {
+				Type:         schema.TypeInt,
+				Description:  "Seconds between IKE rekeys, empty uses the strongSwan default",
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"dpd_delay": {
+				Type:         schema.TypeInt,
+				Description:  "Seconds between dead peer detection checks, empty disables them",
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"dpd_timeout": {
+				Type:         schema.TypeInt,
+				Description:  "Seconds after which an unresponsive IKEv1 peer is considered dead",
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "Description of the connection",
+				Optional:    true,
+			},
+		},
+	}
+}
@@ -0,0 +1,134 @@
+package opnsense
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testIPsecConnectionResource(name string) string {
+	return fmt.Sprintf(`
+resource "opnsense_ipsec_psk" "%s" {
+  identity        = "%s.local.example.com"
+  remote_identity = "%s.remote.example.com"
+  key             = "%s-secret"
+}
+
+resource "opnsense_ipsec_connection" "%s" {
+  proposals        = ["aes256-sha256-modp2048", "aes128-sha256-modp2048"]
+  remote_addresses = ["198.51.100.10"]
+  dpd_delay        = 30
+  description      = "%s"
+}
+
+resource "opnsense_ipsec_local_auth" "%s" {
+  connection_id = opnsense_ipsec_connection.%s.id
+  auth          = "psk"
+  identity      = opnsense_ipsec_psk.%s.identity
+}
+
+resource "opnsense_ipsec_remote_auth" "%s" {
+  connection_id = opnsense_ipsec_connection.%s.id
+  auth          = "psk"
+  identity      = opnsense_ipsec_psk.%s.remote_identity
+}
+
+resource "opnsense_ipsec_child" "%s" {
+  connection_id            = opnsense_ipsec_connection.%s.id
+  esp_proposals            = ["aes256gcm16-modp2048"]
+  local_traffic_selectors  = ["10.10.0.0/16"]
+  remote_traffic_selectors = ["10.20.0.0/16"]
+}
+`, name, name, name, name,
+		name, name,
+		name, name, name,
+		name, name, name,
+		name, name)
+}
+
+func testIPsecStatusData(name string) string {
+	return testIPsecConnectionResource(name) + `
+data "opnsense_ipsec_status" "all" {
+  depends_on = [opnsense_ipsec_child.` + name + `]
+}
+`
+}
+
+func testAccIPsecConnectionResourceDestroy(s *terraform.State) error {
+	items := map[string]*mvcItem{
+		"opnsense_ipsec_psk":         ipsecPSKItem,
+		"opnsense_ipsec_connection":  ipsecConnectionItem,
+		"opnsense_ipsec_local_auth":  ipsecLocalAuthItem,
+		"opnsense_ipsec_remote_auth": ipsecRemoteAuthItem,
+		"opnsense_ipsec_child":       ipsecChildItem,
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		item, ok := items[rs.Type]
+		if !ok {
+			continue
+		}
+
+		err := testAccCheckMvcItemDestroyed(item, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestIPsecConnection_psk(t *testing.T) {
+	rName := fmt.Sprintf("a%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccIPsecConnectionResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testIPsecConnectionResource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr(
+						fmt.Sprintf("opnsense_ipsec_connection.%s", rName),
+						"proposals.*",
+						"aes256-sha256-modp2048",
+					),
+					resource.TestCheckTypeSetElemAttr(
+						fmt.Sprintf("opnsense_ipsec_connection.%s", rName),
+						"proposals.*",
+						"aes128-sha256-modp2048",
+					),
+					resource.TestCheckResourceAttrPair(
+						fmt.Sprintf("opnsense_ipsec_child.%s", rName),
+						"connection_id",
+						fmt.Sprintf("opnsense_ipsec_connection.%s", rName),
+						"id",
+					),
+					resource.TestCheckResourceAttr(
+						fmt.Sprintf("opnsense_ipsec_psk.%s", rName),
+						"key",
+						fmt.Sprintf("%s-secret", rName),
+					),
+				),
+			},
+			{
+				Config: testIPsecStatusData(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"data.opnsense_ipsec_status.all",
+						"ike_sa.#",
+					),
+				),
+			},
+			{
+				ResourceName:      fmt.Sprintf("opnsense_ipsec_child.%s", rName),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
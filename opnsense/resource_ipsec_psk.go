@@ -0,0 +1,90 @@
+package opnsense
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type ipsecPSK struct {
+	Ident       string    `json:"ident"`
+	RemoteIdent string    `json:"remote_ident"`
+	KeyType     mvcOption `json:"keyType"`
+	Key         string    `json:"Key"`
+	Description string    `json:"description"`
+}
+
+var ipsecPSKItem = &mvcItem{
+	controller:  "ipsec/pre_shared_keys",
+	name:        "Item",
+	key:         "preSharedKey",
+	reconfigure: ipsecReconfigure,
+
+	newItem: func() interface{} { return &ipsecPSK{} },
+	expand: func(d *schema.ResourceData) (interface{}, error) {
+		return &ipsecPSK{
+			Ident:       d.Get("identity").(string),
+			RemoteIdent: d.Get("remote_identity").(string),
+			KeyType:     mvcOption(d.Get("type").(string)),
+			Key:         d.Get("key").(string),
+			Description: d.Get("description").(string),
+		}, nil
+	},
+	flatten: func(item interface{}) map[string]interface{} {
+		psk := item.(*ipsecPSK)
+
+		return map[string]interface{}{
+			"identity":        psk.Ident,
+			"remote_identity": psk.RemoteIdent,
+			"type":            string(psk.KeyType),
+			"key":             psk.Key,
+			"description":     psk.Description,
+		}
+	},
+}
+
+func resourceIPsecPSK() *schema.Resource {
+	return &schema.Resource{
+		Description: "A pre-shared key used by IPsec authentication rounds with psk or eap-mschapv2.",
+
+		CreateContext: ipsecPSKItem.create,
+		ReadContext:   ipsecPSKItem.read,
+		UpdateContext: ipsecPSKItem.update,
+		DeleteContext: ipsecPSKItem.delete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"identity": {
+				Type:        schema.TypeString,
+				Description: "Local identity the key belongs to",
+				Required:    true,
+			},
+			"remote_identity": {
+				Type:        schema.TypeString,
+				Description: "Remote identity the key belongs to, empty matches any peer",
+				Optional:    true,
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Description:  "Key type, PSK for IKE or EAP for EAP-MSCHAPv2 users",
+				Optional:     true,
+				Default:      "PSK",
+				ValidateFunc: validation.StringInSlice([]string{"PSK", "EAP"}, false),
+			},
+			"key": {
+				Type:         schema.TypeString,
+				Description:  "The pre-shared key",
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringLenBetween(1, 1024),
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "Description of the key",
+				Optional:    true,
+			},
+		},
+	}
+}
@@ -0,0 +1,139 @@
+package opnsense
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type openVPNClientOverride struct {
+	Enabled         mvcBool `json:"enabled"`
+	Servers         mvcList `json:"servers"`
+	CommonName      string  `json:"common_name"`
+	Block           mvcBool `json:"block"`
+	PushReset       mvcBool `json:"push_reset"`
+	TunnelNetwork   string  `json:"tunnel_network"`
+	TunnelNetworkV6 string  `json:"tunnel_networkv6"`
+	LocalNetworks   mvcList `json:"local_networks"`
+	RemoteNetworks  mvcList `json:"remote_networks"`
+	Description     string  `json:"description"`
+}
+
+var openVPNClientOverrideItem = &mvcItem{
+	controller:  "openvpn/client_overwrites",
+	key:         "cso",
+	reconfigure: openVPNReconfigure,
+
+	newItem: func() interface{} { return &openVPNClientOverride{} },
+	expand: func(d *schema.ResourceData) (interface{}, error) {
+		return &openVPNClientOverride{
+			Enabled:         mvcBool(d.Get("enabled").(bool)),
+			Servers:         expandStringSet(d.Get("servers").(*schema.Set)),
+			CommonName:      d.Get("common_name").(string),
+			Block:           mvcBool(d.Get("block").(bool)),
+			PushReset:       mvcBool(d.Get("push_reset").(bool)),
+			TunnelNetwork:   d.Get("tunnel_address").(string),
+			TunnelNetworkV6: d.Get("tunnel_address6").(string),
+			LocalNetworks:   expandStringSet(d.Get("push_routes").(*schema.Set)),
+			RemoteNetworks:  expandStringSet(d.Get("remote_networks").(*schema.Set)),
+			Description:     d.Get("description").(string),
+		}, nil
+	},
+	flatten: func(item interface{}) map[string]interface{} {
+		override := item.(*openVPNClientOverride)
+
+		return map[string]interface{}{
+			"enabled":         bool(override.Enabled),
+			"servers":         []string(override.Servers),
+			"common_name":     override.CommonName,
+			"block":           bool(override.Block),
+			"push_reset":      bool(override.PushReset),
+			"tunnel_address":  override.TunnelNetwork,
+			"tunnel_address6": override.TunnelNetworkV6,
+			"push_routes":     []string(override.LocalNetworks),
+			"remote_networks": []string(override.RemoteNetworks),
+			"description":     override.Description,
+		}
+	},
+}
+
+func resourceOpenVPNClientOverride() *schema.Resource {
+	return &schema.Resource{
+		Description: "Settings for a single client of an OpenVPN server, matched by the common name of its certificate.",
+
+		CreateContext: openVPNClientOverrideItem.create,
+		ReadContext:   openVPNClientOverrideItem.read,
+		UpdateContext: openVPNClientOverrideItem.update,
+		DeleteContext: openVPNClientOverrideItem.delete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Description: "Enable the override",
+				Optional:    true,
+				Default:     true,
+			},
+			"servers": {
+				Type:        schema.TypeSet,
+				Description: "vpn_id of the opnsense_openvpn_instance servers the override applies to, empty applies to all",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"common_name": {
+				Type:        schema.TypeString,
+				Description: "Common name of the client certificate",
+				Required:    true,
+			},
+			"block": {
+				Type:        schema.TypeBool,
+				Description: "Refuse connections of the client",
+				Optional:    true,
+				Default:     false,
+			},
+			"push_reset": {
+				Type:        schema.TypeBool,
+				Description: "Do not push the options of the server to the client",
+				Optional:    true,
+				Default:     false,
+			},
+			"tunnel_address": {
+				Type:         schema.TypeString,
+				Description:  "Fixed IPv4 tunnel address of the client, e.g. 10.8.0.10/24",
+				Optional:     true,
+				ValidateFunc: validation.Any(validation.StringIsEmpty, validation.IsCIDR),
+			},
+			"tunnel_address6": {
+				Type:         schema.TypeString,
+				Description:  "Fixed IPv6 tunnel address of the client",
+				Optional:     true,
+				ValidateFunc: validation.Any(validation.StringIsEmpty, validation.IsCIDR),
+			},
+			"push_routes": {
+				Type:        schema.TypeSet,
+				Description: "Networks pushed to the client as routes",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsCIDRNetwork(0, 128),
+				},
+			},
+			"remote_networks": {
+				Type:        schema.TypeSet,
+				Description: "Networks behind the client routed through its tunnel",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsCIDRNetwork(0, 128),
+				},
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "Description of the override",
+				Optional:    true,
+			},
+		},
+	}
+}
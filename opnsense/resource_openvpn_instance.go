@@ -0,0 +1,236 @@
+package opnsense
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const openVPNRoleServer = "server"
+
+type openVPNInstance struct {
+	Enabled          mvcBool   `json:"enabled"`
+	VPNID            string    `json:"vpnid,omitempty"`
+	Role             mvcOption `json:"role"`
+	DevType          mvcOption `json:"dev_type"`
+	Proto            mvcOption `json:"proto"`
+	Port             mvcInt    `json:"port"`
+	Topology         mvcOption `json:"topology"`
+	Remote           mvcList   `json:"remote"`
+	Server           string    `json:"server"`
+	ServerIPv6       string    `json:"server_ipv6"`
+	Route            mvcList   `json:"route"`
+	PushRoute        mvcList   `json:"push_route"`
+	Cert             mvcOption `json:"cert"`
+	CA               mvcOption `json:"ca"`
+	VerifyClientCert mvcOption `json:"verify_client_cert"`
+	TLSKey           mvcOption `json:"tls_key"`
+	AuthMode         mvcList   `json:"authmode"`
+	DNSDomain        string    `json:"dns_domain"`
+	DNSServers       mvcList   `json:"dns_servers"`
+	Description      string    `json:"description"`
+}
+
+var openVPNReconfigure = mvcReconfigure("openvpn/service/reconfigure")
+
+var openVPNInstanceItem = &mvcItem{
+	controller:  "openvpn/instances",
+	key:         "instance",
+	reconfigure: openVPNReconfigure,
+
+	newItem: func() interface{} { return &openVPNInstance{} },
+	expand: func(d *schema.ResourceData) (interface{}, error) {
+		return &openVPNInstance{
+			Enabled:          mvcBool(d.Get("enabled").(bool)),
+			Role:             mvcOption(d.Get("role").(string)),
+			DevType:          mvcOption(d.Get("device_type").(string)),
+			Proto:            mvcOption(d.Get("protocol").(string)),
+			Port:             mvcInt(d.Get("port").(int)),
+			Topology:         mvcOption(d.Get("topology").(string)),
+			Remote:           expandStringList(d.Get("remote").([]interface{})),
+			Server:           d.Get("tunnel_network").(string),
+			ServerIPv6:       d.Get("tunnel_network6").(string),
+			Route:            expandStringSet(d.Get("local_networks").(*schema.Set)),
+			PushRoute:        expandStringSet(d.Get("push_routes").(*schema.Set)),
+			Cert:             mvcOption(d.Get("certificate").(string)),
+			CA:               mvcOption(d.Get("ca").(string)),
+			VerifyClientCert: mvcOption(d.Get("verify_client_certificate").(string)),
+			TLSKey:           mvcOption(d.Get("tls_key").(string)),
+			AuthMode:         expandStringSet(d.Get("auth_mode").(*schema.Set)),
+			DNSDomain:        d.Get("dns_domain").(string),
+			DNSServers:       expandStringList(d.Get("dns_servers").([]interface{})),
+			Description:      d.Get("description").(string),
+		}, nil
+	},
+	flatten: func(item interface{}) map[string]interface{} {
+		instance := item.(*openVPNInstance)
+
+		return map[string]interface{}{
+			"enabled":                   bool(instance.Enabled),
+			"vpn_id":                    instance.VPNID,
+			"role":                      string(instance.Role),
+			"device_type":               string(instance.DevType),
+			"protocol":                  string(instance.Proto),
+			"port":                      int(instance.Port),
+			"topology":                  string(instance.Topology),
+			"remote":                    []string(instance.Remote),
+			"tunnel_network":            instance.Server,
+			"tunnel_network6":           instance.ServerIPv6,
+			"local_networks":            []string(instance.Route),
+			"push_routes":               []string(instance.PushRoute),
+			"certificate":               string(instance.Cert),
+			"ca":                        string(instance.CA),
+			"verify_client_certificate": string(instance.VerifyClientCert),
+			"tls_key":                   string(instance.TLSKey),
+			"auth_mode":                 []string(instance.AuthMode),
+			"dns_domain":                instance.DNSDomain,
+			"dns_servers":               []string(instance.DNSServers),
+			"description":               instance.Description,
+		}
+	},
+}
+
+func resourceOpenVPNInstance() *schema.Resource {
+	return &schema.Resource{
+		Description: "An OpenVPN server or client instance.",
+
+		CreateContext: openVPNInstanceItem.create,
+		ReadContext:   openVPNInstanceItem.read,
+		UpdateContext: openVPNInstanceItem.update,
+		DeleteContext: openVPNInstanceItem.delete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Description: "Enable the instance",
+				Optional:    true,
+				Default:     true,
+			},
+			"vpn_id": {
+				Type:        schema.TypeString,
+				Description: "Numeric ID of the instance, used by overrides and the client export",
+				Computed:    true,
+			},
+			"role": {
+				Type:         schema.TypeString,
+				Description:  "Role of the instance, server or client",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{openVPNRoleServer, "client"}, false),
+			},
+			"device_type": {
+				Type:         schema.TypeString,
+				Description:  "Device type, tun for routed or tap for bridged tunnels",
+				Optional:     true,
+				Default:      "tun",
+				ValidateFunc: validation.StringInSlice([]string{"tun", "tap"}, false),
+			},
+			"protocol": {
+				Type:         schema.TypeString,
+				Description:  "Transport protocol, e.g. udp or tcp4",
+				Optional:     true,
+				Default:      "udp",
+				ValidateFunc: validation.StringInSlice([]string{"udp", "udp4", "udp6", "tcp", "tcp4", "tcp6"}, false),
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Description:  "Port to listen on, empty uses 1194 for servers",
+				Optional:     true,
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"topology": {
+				Type:         schema.TypeString,
+				Description:  "Topology of the tunnel network, one of subnet, net30 or p2p",
+				Optional:     true,
+				Default:      "subnet",
+				ValidateFunc: validation.StringInSlice([]string{"subnet", "net30", "p2p"}, false),
+			},
+			"remote": {
+				Type:        schema.TypeList,
+				Description: "Servers a client connects to as host or host:port",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"tunnel_network": {
+				Type:         schema.TypeString,
+				Description:  "IPv4 network client addresses are taken from",
+				Optional:     true,
+				ValidateFunc: validation.Any(validation.StringIsEmpty, validation.IsCIDRNetwork(0, 32)),
+			},
+			"tunnel_network6": {
+				Type:         schema.TypeString,
+				Description:  "IPv6 network client addresses are taken from",
+				Optional:     true,
+				ValidateFunc: validation.Any(validation.StringIsEmpty, validation.IsCIDRNetwork(0, 128)),
+			},
+			"local_networks": {
+				Type:        schema.TypeSet,
+				Description: "Networks routed through the tunnel to the remote side",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsCIDRNetwork(0, 128),
+				},
+			},
+			"push_routes": {
+				Type:        schema.TypeSet,
+				Description: "Networks pushed to clients as routes",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsCIDRNetwork(0, 128),
+				},
+			},
+			"certificate": {
+				Type:        schema.TypeString,
+				Description: "Reference ID of the certificate of the instance",
+				Optional:    true,
+			},
+			"ca": {
+				Type:        schema.TypeString,
+				Description: "Reference ID of the CA peer certificates are verified against",
+				Optional:    true,
+			},
+			"verify_client_certificate": {
+				Type:         schema.TypeString,
+				Description:  "Client certificate requirement of a server, none or require",
+				Optional:     true,
+				Default:      "require",
+				ValidateFunc: validation.StringInSlice([]string{"none", "require"}, false),
+			},
+			"tls_key": {
+				Type:        schema.TypeString,
+				Description: "ID of the static key used for TLS authentication or encryption",
+				Optional:    true,
+			},
+			"auth_mode": {
+				Type:        schema.TypeSet,
+				Description: "Authentication servers users of a server are verified against, e.g. Local Database",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"dns_domain": {
+				Type:        schema.TypeString,
+				Description: "DNS domain pushed to clients",
+				Optional:    true,
+			},
+			"dns_servers": {
+				Type:        schema.TypeList,
+				Description: "DNS servers pushed to clients",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsIPAddress,
+				},
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "Description of the instance",
+				Optional:    true,
+			},
+		},
+	}
+}
@@ -0,0 +1,90 @@
+package opnsense
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testOpenVPNInstanceResource(name string, block bool) string {
+	return fmt.Sprintf(`
+resource "opnsense_openvpn_instance" "%s" {
+  role        = "client"
+  protocol    = "udp4"
+  remote      = ["vpn.example.com:1194"]
+  description = "%s"
+}
+
+resource "opnsense_openvpn_client_override" "%s" {
+  common_name    = "%s.example.com"
+  tunnel_address = "10.8.0.10/24"
+  push_routes    = ["10.10.0.0/16"]
+  block          = %t
+}
+`, name, name, name, name, block)
+}
+
+func testAccOpenVPNInstanceResourceDestroy(s *terraform.State) error {
+	items := map[string]*mvcItem{
+		"opnsense_openvpn_instance":        openVPNInstanceItem,
+		"opnsense_openvpn_client_override": openVPNClientOverrideItem,
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		item, ok := items[rs.Type]
+		if !ok {
+			continue
+		}
+
+		err := testAccCheckMvcItemDestroyed(item, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestOpenVPNInstance_override(t *testing.T) {
+	rName := fmt.Sprintf("a%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccOpenVPNInstanceResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testOpenVPNInstanceResource(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						fmt.Sprintf("opnsense_openvpn_instance.%s", rName),
+						"vpn_id",
+					),
+					resource.TestCheckResourceAttr(
+						fmt.Sprintf("opnsense_openvpn_client_override.%s", rName),
+						"block",
+						"false",
+					),
+				),
+			},
+			{
+				Config: testOpenVPNInstanceResource(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						fmt.Sprintf("opnsense_openvpn_client_override.%s", rName),
+						"block",
+						"true",
+					),
+				),
+			},
+			{
+				ResourceName:      fmt.Sprintf("opnsense_openvpn_instance.%s", rName),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,78 @@
+package opnsense
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type route struct {
+	Disabled    mvcBool   `json:"disabled"`
+	Network     string    `json:"network"`
+	Gateway     mvcOption `json:"gateway"`
+	Description string    `json:"descr"`
+}
+
+var routeItem = &mvcItem{
+	controller:  "routes/routes",
+	name:        "route",
+	key:         "route",
+	reconfigure: mvcReconfigure("routes/routes/reconfigure"),
+
+	newItem: func() interface{} { return &route{} },
+	expand: func(d *schema.ResourceData) (interface{}, error) {
+		return &route{
+			Disabled:    mvcBool(!d.Get("enabled").(bool)),
+			Network:     d.Get("network").(string),
+			Gateway:     mvcOption(d.Get("gateway").(string)),
+			Description: d.Get("description").(string),
+		}, nil
+	},
+	flatten: func(item interface{}) map[string]interface{} {
+		r := item.(*route)
+
+		return map[string]interface{}{
+			"enabled":     !bool(r.Disabled),
+			"network":     r.Network,
+			"gateway":     string(r.Gateway),
+			"description": r.Description,
+		}
+	},
+}
+
+func resourceRoute() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: routeItem.create,
+		ReadContext:   routeItem.read,
+		UpdateContext: routeItem.update,
+		DeleteContext: routeItem.delete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Description: "Enable the route",
+				Optional:    true,
+				Default:     true,
+			},
+			"network": {
+				Type:         schema.TypeString,
+				Description:  "Destination network, e.g. 10.10.0.0/16",
+				Required:     true,
+				ValidateFunc: validation.IsCIDRNetwork(0, 128),
+			},
+			"gateway": {
+				Type:        schema.TypeString,
+				Description: "Name of the gateway the network is routed through",
+				Required:    true,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "Description of the route",
+				Optional:    true,
+			},
+		},
+	}
+}
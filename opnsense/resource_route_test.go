@@ -0,0 +1,101 @@
+package opnsense
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testRouteResource(name string) string {
+	return fmt.Sprintf(`
+resource "opnsense_routing_gateway" "%s" {
+  name            = "%s"
+  interface       = "lan"
+  address         = "192.168.1.254"
+  monitor_address = "192.168.1.253"
+  priority        = 200
+  latency_low     = 100
+  latency_high    = 300
+  description     = "%s"
+}
+
+resource "opnsense_route" "%s" {
+  network     = "10.123.0.0/16"
+  gateway     = opnsense_routing_gateway.%s.name
+  description = "%s"
+}
+`, name, name, name, name, name, name)
+}
+
+func testRouteGatewayStatusData(name string) string {
+	return testRouteResource(name) + `
+data "opnsense_routing_gateway_status" "all" {
+  depends_on = [opnsense_routing_gateway.` + name + `]
+}
+`
+}
+
+func testAccRouteResourceDestroy(s *terraform.State) error {
+	items := map[string]*mvcItem{
+		"opnsense_routing_gateway": routingGatewayItem,
+		"opnsense_route":           routeItem,
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		item, ok := items[rs.Type]
+		if !ok {
+			continue
+		}
+
+		err := testAccCheckMvcItemDestroyed(item, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestRoute_gateway(t *testing.T) {
+	rName := fmt.Sprintf("a%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccRouteResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testRouteResource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						fmt.Sprintf("opnsense_routing_gateway.%s", rName),
+						"latency_high",
+						"300",
+					),
+					resource.TestCheckResourceAttr(
+						fmt.Sprintf("opnsense_route.%s", rName),
+						"gateway",
+						rName,
+					),
+				),
+			},
+			{
+				Config: testRouteGatewayStatusData(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"data.opnsense_routing_gateway_status.all",
+						"gateway.0.status",
+					),
+				),
+			},
+			{
+				ResourceName:      fmt.Sprintf("opnsense_route.%s", rName),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,209 @@
+package opnsense
+
+import (
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type routingGateway struct {
+	Disabled       mvcBool   `json:"disabled"`
+	Name           string    `json:"name"`
+	Description    string    `json:"descr"`
+	Interface      mvcOption `json:"interface"`
+	IPProtocol     mvcOption `json:"ipprotocol"`
+	Gateway        string    `json:"gateway"`
+	DefaultGW      mvcBool   `json:"defaultgw"`
+	FarGW          mvcBool   `json:"fargw"`
+	MonitorDisable mvcBool   `json:"monitor_disable"`
+	Monitor        string    `json:"monitor"`
+	ForceDown      mvcBool   `json:"force_down"`
+	Priority       mvcNumber `json:"priority"`
+	Weight         mvcInt    `json:"weight"`
+	LatencyLow     mvcInt    `json:"latencylow"`
+	LatencyHigh    mvcInt    `json:"latencyhigh"`
+	LossLow        mvcInt    `json:"losslow"`
+	LossHigh       mvcInt    `json:"losshigh"`
+}
+
+var routingGatewayItem = &mvcItem{
+	controller:  "routing/settings",
+	name:        "Gateway",
+	key:         "gateway_item",
+	reconfigure: mvcReconfigure("routing/settings/reconfigure"),
+
+	newItem: func() interface{} { return &routingGateway{} },
+	expand: func(d *schema.ResourceData) (interface{}, error) {
+		return &routingGateway{
+			Disabled:       mvcBool(!d.Get("enabled").(bool)),
+			Name:           d.Get("name").(string),
+			Description:    d.Get("description").(string),
+			Interface:      mvcOption(d.Get("interface").(string)),
+			IPProtocol:     mvcOption(d.Get("ipprotocol").(string)),
+			Gateway:        d.Get("address").(string),
+			DefaultGW:      mvcBool(d.Get("default").(bool)),
+			FarGW:          mvcBool(d.Get("far_gateway").(bool)),
+			MonitorDisable: mvcBool(!d.Get("monitor_enabled").(bool)),
+			Monitor:        d.Get("monitor_address").(string),
+			ForceDown:      mvcBool(d.Get("force_down").(bool)),
+			Priority:       mvcNumber(d.Get("priority").(int)),
+			Weight:         mvcInt(d.Get("weight").(int)),
+			LatencyLow:     mvcInt(d.Get("latency_low").(int)),
+			LatencyHigh:    mvcInt(d.Get("latency_high").(int)),
+			LossLow:        mvcInt(d.Get("loss_low").(int)),
+			LossHigh:       mvcInt(d.Get("loss_high").(int)),
+		}, nil
+	},
+	flatten: func(item interface{}) map[string]interface{} {
+		gateway := item.(*routingGateway)
+
+		return map[string]interface{}{
+			"enabled":         !bool(gateway.Disabled),
+			"name":            gateway.Name,
+			"description":     gateway.Description,
+			"interface":       string(gateway.Interface),
+			"ipprotocol":      string(gateway.IPProtocol),
+			"address":         gateway.Gateway,
+			"default":         bool(gateway.DefaultGW),
+			"far_gateway":     bool(gateway.FarGW),
+			"monitor_enabled": !bool(gateway.MonitorDisable),
+			"monitor_address": gateway.Monitor,
+			"force_down":      bool(gateway.ForceDown),
+			"priority":        int(gateway.Priority),
+			"weight":          int(gateway.Weight),
+			"latency_low":     int(gateway.LatencyLow),
+			"latency_high":    int(gateway.LatencyHigh),
+			"loss_low":        int(gateway.LossLow),
+			"loss_high":       int(gateway.LossHigh),
+		}
+	},
+}
+
+func resourceRoutingGateway() *schema.Resource {
+	return &schema.Resource{
+		Description: "A gateway, use its name as gateway of opnsense_firewall_filter_rule and opnsense_route.",
+
+		CreateContext: routingGatewayItem.create,
+		ReadContext:   routingGatewayItem.read,
+		UpdateContext: routingGatewayItem.update,
+		DeleteContext: routingGatewayItem.delete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Description: "Enable the gateway",
+				Optional:    true,
+				Default:     true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "Name of the gateway",
+				Required:    true,
+				ValidateFunc: validation.StringMatch(
+					regexp.MustCompile(`^[a-zA-Z0-9_]{1,32}$`),
+					"must be at most 32 letters, digits or underscores",
+				),
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "Description of the gateway",
+				Optional:    true,
+			},
+			"interface": {
+				Type:        schema.TypeString,
+				Description: "Interface the gateway is reached through, e.g. wan",
+				Required:    true,
+			},
+			"ipprotocol": {
+				Type:         schema.TypeString,
+				Description:  "Address family of the gateway, inet or inet6",
+				Optional:     true,
+				Default:      "inet",
+				ValidateFunc: validation.StringInSlice([]string{"inet", "inet6"}, false),
+			},
+			"address": {
+				Type:         schema.TypeString,
+				Description:  "Address of the gateway",
+				Required:     true,
+				ValidateFunc: validation.IsIPAddress,
+			},
+			"default": {
+				Type:        schema.TypeBool,
+				Description: "Use as default gateway",
+				Optional:    true,
+				Default:     false,
+			},
+			"far_gateway": {
+				Type:        schema.TypeBool,
+				Description: "Allow a gateway outside of the interface subnet",
+				Optional:    true,
+				Default:     false,
+			},
+			"monitor_enabled": {
+				Type:        schema.TypeBool,
+				Description: "Monitor the gateway with ICMP",
+				Optional:    true,
+				Default:     true,
+			},
+			"monitor_address": {
+				Type:         schema.TypeString,
+				Description:  "Address to monitor instead of the gateway address",
+				Optional:     true,
+				ValidateFunc: validation.Any(validation.StringIsEmpty, validation.IsIPAddress),
+			},
+			"force_down": {
+				Type:        schema.TypeBool,
+				Description: "Mark the gateway as down",
+				Optional:    true,
+				Default:     false,
+			},
+			"priority": {
+				Type:         schema.TypeInt,
+				Description:  "Priority of the gateway, the lowest is used as default gateway",
+				Optional:     true,
+				Default:      255,
+				ValidateFunc: validation.IntBetween(0, 255),
+			},
+			"weight": {
+				Type:         schema.TypeInt,
+				Description:  "Weight for load balancing within a gateway group tier",
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntBetween(1, 5),
+			},
+			"latency_low": {
+				Type:         schema.TypeInt,
+				Description:  "Latency in milliseconds above which the gateway is warned about",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"latency_high": {
+				Type:         schema.TypeInt,
+				Description:  "Latency in milliseconds above which the gateway is considered down",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"loss_low": {
+				Type:         schema.TypeInt,
+				Description:  "Packet loss in percent above which the gateway is warned about",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(1, 100),
+			},
+			"loss_high": {
+				Type:         schema.TypeInt,
+				Description:  "Packet loss in percent above which the gateway is considered down",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(1, 100),
+			},
+		},
+	}
+}
@@ -0,0 +1,137 @@
+package opnsense
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/kradalby/opnsense-go/opnsense"
+)
+
+type systemGroup struct {
+	Name        string  `json:"name"`
+	GID         string  `json:"gid,omitempty"`
+	Description string  `json:"description"`
+	Members     mvcList `json:"member"`
+	Privileges  mvcList `json:"priv"`
+}
+
+type privilegeRow struct {
+	ID string `json:"id"`
+}
+
+var systemGroupItem = &mvcItem{
+	controller: "auth/group",
+	key:        "group",
+
+	newItem: func() interface{} { return &systemGroup{} },
+	expand: func(d *schema.ResourceData) (interface{}, error) {
+		return &systemGroup{
+			Name:        d.Get("name").(string),
+			Description: d.Get("description").(string),
+			Members:     expandStringSet(d.Get("members").(*schema.Set)),
+			Privileges:  expandStringSet(d.Get("privileges").(*schema.Set)),
+		}, nil
+	},
+	flatten: func(item interface{}) map[string]interface{} {
+		group := item.(*systemGroup)
+
+		return map[string]interface{}{
+			"name":        group.Name,
+			"gid":         group.GID,
+			"description": group.Description,
+			"members":     []string(group.Members),
+			"privileges":  []string(group.Privileges),
+		}
+	},
+}
+
+func resourceSystemGroup() *schema.Resource {
+	return &schema.Resource{
+		Description: "A local group. Manage memberships either with members here or with " +
+			"groups of opnsense_system_user, not both.",
+
+		CreateContext: systemGroupItem.create,
+		ReadContext:   systemGroupItem.read,
+		UpdateContext: systemGroupItem.update,
+		DeleteContext: systemGroupItem.delete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		CustomizeDiff: resourceSystemGroupCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Description: "Name of the group",
+				Required:    true,
+			},
+			"gid": {
+				Type:        schema.TypeString,
+				Description: "Numeric ID of the group",
+				Computed:    true,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "Description of the group",
+				Optional:    true,
+			},
+			"members": {
+				Type:        schema.TypeSet,
+				Description: "uid of the opnsense_system_user members",
+				Optional:    true,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"privileges": {
+				Type:        schema.TypeSet,
+				Description: "Privileges of the members, e.g. page-all or page-firewall-rules",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceSystemGroupCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if meta == nil || meta.(*providerMeta).privileges == nil || !d.NewValueKnown("privileges") {
+		return nil
+	}
+
+	privileges := meta.(*providerMeta).privileges
+	unknown := []string{}
+
+	for _, privilege := range expandStringSet(d.Get("privileges").(*schema.Set)) {
+		if !privileges[privilege] {
+			unknown = append(unknown, privilege)
+		}
+	}
+
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+
+		return fmt.Errorf("privileges %v do not exist on this OPNsense", unknown)
+	}
+
+	return nil
+}
+
+// privilegeList fetches the names of all privileges in the ACL.
+func privilegeList(c *opnsense.Client) (map[string]bool, error) {
+	rows := []privilegeRow{}
+
+	err := mvcSearch(c, "auth/priv/search", &rows)
+	if err != nil {
+		return nil, err
+	}
+
+	privileges := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		privileges[row.ID] = true
+	}
+
+	return privileges, nil
+}
@@ -0,0 +1,177 @@
+package opnsense
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type systemUser struct {
+	Disabled          mvcBool   `json:"disabled"`
+	Name              string    `json:"name"`
+	UID               string    `json:"uid,omitempty"`
+	FullName          string    `json:"descr"`
+	Email             string    `json:"email"`
+	Comment           string    `json:"comment"`
+	Password          string    `json:"password,omitempty"`
+	ScrambledPassword mvcBool   `json:"scrambled_password"`
+	Shell             mvcOption `json:"shell"`
+	Expires           string    `json:"expires"`
+	GroupMemberships  mvcList   `json:"group_memberships"`
+	AuthorizedKeys    string    `json:"authorizedkeys"`
+	OTPSeed           string    `json:"otp_seed"`
+}
+
+var systemUserItem = &mvcItem{
+	controller: "auth/user",
+	key:        "user",
+
+	newItem: func() interface{} { return &systemUser{} },
+	expand: func(d *schema.ResourceData) (interface{}, error) {
+		user := &systemUser{
+			Disabled:         mvcBool(!d.Get("enabled").(bool)),
+			Name:             d.Get("name").(string),
+			FullName:         d.Get("full_name").(string),
+			Email:            d.Get("email").(string),
+			Comment:          d.Get("comment").(string),
+			Shell:            mvcOption(d.Get("shell").(string)),
+			Expires:          d.Get("expires").(string),
+			GroupMemberships: expandStringSet(d.Get("groups").(*schema.Set)),
+			AuthorizedKeys:   strings.Join(expandStringList(d.Get("authorized_keys").([]interface{})), "\n"),
+			OTPSeed:          d.Get("otp_seed").(string),
+		}
+
+		// The password is only sent when set or changed, OPNsense stores a hash
+		if d.IsNewResource() || d.HasChange("password") || d.HasChange("generate_password") {
+			user.Password = d.Get("password").(string)
+			user.ScrambledPassword = mvcBool(d.Get("generate_password").(bool))
+		}
+
+		return user, nil
+	},
+	flatten: func(item interface{}) map[string]interface{} {
+		user := item.(*systemUser)
+
+		keys := []string{}
+
+		for _, key := range strings.Split(user.AuthorizedKeys, "\n") {
+			if key = strings.TrimSpace(key); key != "" {
+				keys = append(keys, key)
+			}
+		}
+
+		return map[string]interface{}{
+			"enabled":         !bool(user.Disabled),
+			"name":            user.Name,
+			"uid":             user.UID,
+			"full_name":       user.FullName,
+			"email":           user.Email,
+			"comment":         user.Comment,
+			"shell":           string(user.Shell),
+			"expires":         user.Expires,
+			"groups":          []string(user.GroupMemberships),
+			"authorized_keys": keys,
+			"otp_seed":        user.OTPSeed,
+		}
+	},
+}
+
+func resourceSystemUser() *schema.Resource {
+	return &schema.Resource{
+		Description: "A local user. Manage memberships either with groups here or with " +
+			"members of opnsense_system_group, not both. The user API only takes a plaintext " +
+			"password, a password hash can not be set.",
+
+		CreateContext: systemUserItem.create,
+		ReadContext:   systemUserItem.read,
+		UpdateContext: systemUserItem.update,
+		DeleteContext: systemUserItem.delete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Description: "Allow the user to log in",
+				Optional:    true,
+				Default:     true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "Login name of the user",
+				Required:    true,
+				ValidateFunc: validation.StringMatch(
+					regexp.MustCompile(`^[a-zA-Z0-9._-]{1,32}$`),
+					"must be at most 32 letters, digits, dots, underscores or dashes",
+				),
+			},
+			"uid": {
+				Type:        schema.TypeString,
+				Description: "Numeric ID of the user",
+				Computed:    true,
+			},
+			"full_name": {
+				Type:        schema.TypeString,
+				Description: "Full name of the user",
+				Optional:    true,
+			},
+			"email": {
+				Type:        schema.TypeString,
+				Description: "Email address of the user",
+				Optional:    true,
+			},
+			"comment": {
+				Type:        schema.TypeString,
+				Description: "Comment on the user",
+				Optional:    true,
+			},
+			"password": {
+				Type:          schema.TypeString,
+				Description:   "Plaintext password of the user, kept in the state, OPNsense only stores its hash",
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"generate_password"},
+			},
+			"generate_password": {
+				Type:        schema.TypeBool,
+				Description: "Set a random password, e.g. for users only logging in with SSH keys or API keys",
+				Optional:    true,
+				Default:     false,
+			},
+			"shell": {
+				Type:        schema.TypeString,
+				Description: "Login shell, e.g. /bin/sh, empty disables shell access",
+				Optional:    true,
+			},
+			"expires": {
+				Type:         schema.TypeString,
+				Description:  "Date the account expires in MM/DD/YYYY format",
+				Optional:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^(\d{2}/\d{2}/\d{4})?$`), "must be MM/DD/YYYY"),
+			},
+			"groups": {
+				Type:        schema.TypeSet,
+				Description: "gid of the opnsense_system_group the user is a member of",
+				Optional:    true,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"authorized_keys": {
+				Type:        schema.TypeList,
+				Description: "SSH public keys allowed to log in as the user",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"otp_seed": {
+				Type:        schema.TypeString,
+				Description: "Base32 seed for one-time passwords",
+				Optional:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
@@ -0,0 +1,200 @@
+package opnsense
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/kradalby/opnsense-go/opnsense"
+)
+
+type systemUserAPIKeyRow struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+}
+
+type systemUserAPIKeyResponse struct {
+	Result string `json:"result"`
+	Key    string `json:"key"`
+	Secret string `json:"secret"`
+}
+
+func resourceSystemUserAPIKey() *schema.Resource {
+	return &schema.Resource{
+		Description: "An API key of an opnsense_system_user. The secret is only returned when " +
+			"the key is created, change keepers to rotate the key.",
+
+		CreateContext: resourceSystemUserAPIKeyCreate,
+		ReadContext:   resourceSystemUserAPIKeyRead,
+		DeleteContext: resourceSystemUserAPIKeyDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSystemUserAPIKeyImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"user": {
+				Type:        schema.TypeString,
+				Description: "Name of the opnsense_system_user owning the key",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"keepers": {
+				Type:        schema.TypeMap,
+				Description: "Arbitrary values, changing them replaces the key",
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"key": {
+				Type:        schema.TypeString,
+				Description: "API key, used as key of the provider and as ID to import the key",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"secret": {
+				Type:        schema.TypeString,
+				Description: "API secret, used as secret of the provider, empty after import",
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func resourceSystemUserAPIKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[TRACE] Getting OPNsense client from meta")
+
+	var diags diag.Diagnostics
+
+	c := meta.(*providerMeta).client
+
+	row, err := systemUserAPIKeyGet(c, d.Get("key").(string))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			d.SetId("")
+
+			return diags
+		}
+
+		log.Printf("[ERROR] Failed to fetch API keys")
+
+		return diag.FromErr(err)
+	}
+
+	values := map[string]interface{}{
+		"user": row.Username,
+		"key":  row.ID,
+	}
+
+	err = setResourceData(d, values)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceSystemUserAPIKeyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*providerMeta).client
+
+	api := "auth/user/addApiKey/" + url.PathEscape(d.Get("user").(string))
+	response := systemUserAPIKeyResponse{}
+
+	err := c.PostAndMarshal(api, struct{}{}, &response)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if response.Result != mvcStatusOk || response.Key == "" {
+		return diag.FromErr(fmt.Errorf("%s returned result %q: %w", api, response.Result, ErrStatusNotOk))
+	}
+
+	d.SetId(systemUserAPIKeyID(d.Get("user").(string), response.Key))
+
+	err = setResourceData(d, map[string]interface{}{
+		"key":    response.Key,
+		"secret": response.Secret,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceSystemUserAPIKeyRead(ctx, d, meta)
+}
+
+func resourceSystemUserAPIKeyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*providerMeta).client
+
+	var diags diag.Diagnostics
+
+	key := d.Get("key").(string)
+
+	err := mvcDelete(c, "auth/user/delApiKey/"+url.PathEscape(key))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// mvcDelete treats a key that was not found as removed, check it is gone
+	_, err = systemUserAPIKeyGet(c, key)
+	if err == nil {
+		return diag.FromErr(fmt.Errorf("API key %s still exists after delete", d.Id()))
+	}
+
+	if !errors.Is(err, ErrNotFound) {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// resourceSystemUserAPIKeyImport imports a key by the key itself, the ID
+// is replaced by one without the key.
+func resourceSystemUserAPIKeyImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	c := meta.(*providerMeta).client
+
+	row, err := systemUserAPIKeyGet(c, d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.Set("key", row.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(systemUserAPIKeyID(row.Username, row.ID))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// systemUserAPIKeyID returns the resource ID of a key, the key is hashed
+// as the ID shows up in plans and logs.
+func systemUserAPIKeyID(user string, key string) string {
+	return fmt.Sprintf("%s/%x", user, sha256.Sum256([]byte(key)))
+}
+
+// systemUserAPIKeyGet finds the API key with the given key, secrets are never returned.
+func systemUserAPIKeyGet(c *opnsense.Client, key string) (*systemUserAPIKeyRow, error) {
+	rows := []systemUserAPIKeyRow{}
+
+	err := mvcSearch(c, "auth/user/searchApiKey", &rows)
+	if err != nil {
+		return nil, err
+	}
+
+	for index := range rows {
+		if rows[index].ID == key {
+			return &rows[index], nil
+		}
+	}
+
+	return nil, fmt.Errorf("API key: %w", ErrNotFound)
+}
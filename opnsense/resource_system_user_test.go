@@ -0,0 +1,186 @@
+package opnsense
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testSystemUserResource(name string, privilege string, keeper string) string {
+	return fmt.Sprintf(`
+resource "opnsense_system_group" "%s" {
+  name        = "%s"
+  description = "%s"
+  privileges  = ["page-dashboard-all", "%s"]
+}
+
+resource "opnsense_system_user" "%s" {
+  name              = "%s"
+  full_name         = "%s"
+  generate_password = true
+  shell             = "/bin/sh"
+  expires           = "12/31/2099"
+  groups            = [opnsense_system_group.%s.gid]
+
+  authorized_keys = [
+    "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIJmt0S0nZ7Ez1Z7u0rX4xqfQ8J3xVYkJ5O2tQk0s1XhG %s",
+  ]
+}
+
+resource "opnsense_system_user_apikey" "%s" {
+  user = opnsense_system_user.%s.name
+
+  keepers = {
+    rotation = "%s"
+  }
+}
+`, name, name, name, privilege, name, name, name, name, name, name, name, keeper)
+}
+
+func testAccSystemUserResourceDestroy(s *terraform.State) error {
+	items := map[string]*mvcItem{
+		"opnsense_system_user":  systemUserItem,
+		"opnsense_system_group": systemGroupItem,
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type == "opnsense_system_user_apikey" {
+			_, err := systemUserAPIKeyGet(testAccProvider.Meta().(*providerMeta).client, rs.Primary.Attributes["key"])
+			if errors.Is(err, ErrNotFound) {
+				continue
+			}
+
+			if err != nil {
+				return err
+			}
+
+			return fmt.Errorf("API key %s still exists", rs.Primary.ID)
+		}
+
+		item, ok := items[rs.Type]
+		if !ok {
+			continue
+		}
+
+		err := testAccCheckMvcItemDestroyed(item, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// testAccSystemUserAPIKeyStore stores the key of the named API key in key.
+func testAccSystemUserAPIKeyStore(name string, key *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources["opnsense_system_user_apikey."+name]
+		if !ok {
+			return fmt.Errorf("opnsense_system_user_apikey.%s: %w", name, ErrNotFound)
+		}
+
+		*key = rs.Primary.Attributes["key"]
+
+		return nil
+	}
+}
+
+// testAccCheckSystemUserAPIKeyRemoved checks that the API key key no longer exists.
+func testAccCheckSystemUserAPIKeyRemoved(key *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		_, err := systemUserAPIKeyGet(testAccProvider.Meta().(*providerMeta).client, *key)
+		if errors.Is(err, ErrNotFound) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		return errors.New("replaced API key still exists")
+	}
+}
+
+// testAccSystemUserAPIKeyImportID returns the key, API keys are imported by key.
+func testAccSystemUserAPIKeyImportID(name string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources["opnsense_system_user_apikey."+name]
+		if !ok {
+			return "", fmt.Errorf("opnsense_system_user_apikey.%s: %w", name, ErrNotFound)
+		}
+
+		return rs.Primary.Attributes["key"], nil
+	}
+}
+
+func TestSystemUser_group(t *testing.T) {
+	rName := fmt.Sprintf("a%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	var key string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccSystemUserResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testSystemUserResource(rName, "page-does-not-exist", "1"),
+				ExpectError: regexp.MustCompile("do not exist on this OPNsense"),
+			},
+			{
+				Config: testSystemUserResource(rName, "page-firewall-rules", "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						fmt.Sprintf("opnsense_system_group.%s", rName),
+						"privileges.#",
+						"2",
+					),
+					resource.TestCheckTypeSetElemAttrPair(
+						fmt.Sprintf("opnsense_system_user.%s", rName),
+						"groups.*",
+						fmt.Sprintf("opnsense_system_group.%s", rName),
+						"gid",
+					),
+					resource.TestCheckResourceAttrSet(
+						fmt.Sprintf("opnsense_system_user.%s", rName),
+						"uid",
+					),
+					resource.TestCheckResourceAttrSet(
+						fmt.Sprintf("opnsense_system_user_apikey.%s", rName),
+						"secret",
+					),
+					testAccSystemUserAPIKeyStore(rName, &key),
+				),
+			},
+			{
+				ResourceName:            fmt.Sprintf("opnsense_system_user.%s", rName),
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password", "generate_password"},
+			},
+			{
+				ResourceName:            fmt.Sprintf("opnsense_system_user_apikey.%s", rName),
+				ImportState:             true,
+				ImportStateIdFunc:       testAccSystemUserAPIKeyImportID(rName),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"secret"},
+			},
+			{
+				// Changing keepers replaces the key
+				Config: testSystemUserResource(rName, "page-firewall-rules", "2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSystemUserAPIKeyRemoved(&key),
+					resource.TestCheckResourceAttrSet(
+						fmt.Sprintf("opnsense_system_user_apikey.%s", rName),
+						"secret",
+					),
+				),
+			},
+		},
+	})
+}
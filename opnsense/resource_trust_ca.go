@@ -0,0 +1,493 @@
+package opnsense
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	trustMethodImport = "import"
+	trustMethodSign   = "sign"
+	trustMethodCSR    = "csr"
+	trustMethodCreate = "internal"
+)
+
+var trustKeyTypes = []string{
+	"RSA-2048", "RSA-3072", "RSA-4096", "RSA-8192", "prime256v1", "secp384r1", "secp521r1",
+}
+
+var trustDigests = []string{"sha256", "sha384", "sha512"}
+
+type trustCA struct {
+	RefID              string    `json:"refid,omitempty"`
+	Description        string    `json:"descr"`
+	Action             mvcOption `json:"action,omitempty"`
+	KeyType            mvcOption `json:"key_type,omitempty"`
+	Digest             mvcOption `json:"digest,omitempty"`
+	Lifetime           mvcInt    `json:"lifetime,omitempty"`
+	Country            mvcOption `json:"country,omitempty"`
+	State              string    `json:"state,omitempty"`
+	City               string    `json:"city,omitempty"`
+	Organization       string    `json:"organization,omitempty"`
+	OrganizationalUnit string    `json:"organizationalunit,omitempty"`
+	Email              string    `json:"email,omitempty"`
+	CommonName         string    `json:"commonname,omitempty"`
+	Crt                string    `json:"crt,omitempty"`
+	Prv                string    `json:"prv,omitempty"`
+	CrtPayload         string    `json:"crt_payload,omitempty"`
+	PrvPayload         string    `json:"prv_payload,omitempty"`
+}
+
+var trustCAItem = &mvcItem{
+	controller: "trust/ca",
+	key:        "ca",
+
+	newItem: func() interface{} { return &trustCA{} },
+	expand: func(d *schema.ResourceData) (interface{}, error) {
+		// Everything but the description is fixed once the CA exists
+		if !d.IsNewResource() {
+			return &trustCA{Description: d.Get("description").(string)}, nil
+		}
+
+		ca := &trustCA{
+			Description: d.Get("description").(string),
+		}
+
+		if d.Get("method").(string) == trustMethodImport {
+			ca.Action = "existing"
+			ca.CrtPayload = d.Get("certificate").(string)
+			ca.PrvPayload = d.Get("private_key").(string)
+
+			return ca, nil
+		}
+
+		ca.Action = "internal"
+		ca.KeyType = mvcOption(d.Get("key_type").(string))
+		ca.Digest = mvcOption(d.Get("digest").(string))
+		ca.Lifetime = mvcInt(d.Get("lifetime").(int))
+		ca.Country = mvcOption(d.Get("country").(string))
+		ca.State = d.Get("state").(string)
+		ca.City = d.Get("city").(string)
+		ca.Organization = d.Get("organization").(string)
+		ca.OrganizationalUnit = d.Get("organizational_unit").(string)
+		ca.Email = d.Get("email").(string)
+		ca.CommonName = d.Get("common_name").(string)
+
+		return ca, nil
+	},
+	flatten: func(item interface{}) map[string]interface{} {
+		ca := item.(*trustCA)
+
+		values := map[string]interface{}{
+			"refid":       ca.RefID,
+			"description": ca.Description,
+			"certificate": trustPEM(ca.CrtPayload, ca.Crt),
+			"private_key": trustPEM(ca.PrvPayload, ca.Prv),
+		}
+
+		for k, v := range flattenCertificatePEM(values["certificate"].(string)) {
+			values[k] = v
+		}
+
+		return values
+	},
+}
+
+func resourceTrustCA() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"method": {
+			Type:             schema.TypeString,
+			Description:      "internal to create a new CA or import to import an existing one",
+			Optional:         true,
+			Default:          trustMethodCreate,
+			ForceNew:         true,
+			ValidateFunc:     validation.StringInSlice([]string{trustMethodCreate, trustMethodImport}, false),
+			DiffSuppressFunc: suppressTrustMethod,
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Description: "Description of the CA",
+			Required:    true,
+		},
+		"lifetime": {
+			Type:             schema.TypeInt,
+			Description:      "Lifetime of a created CA in days",
+			Optional:         true,
+			Default:          3650,
+			ForceNew:         true,
+			ValidateFunc:     validation.IntAtLeast(1),
+			DiffSuppressFunc: suppressTrustImported,
+		},
+	}
+
+	addTrustSchema(s)
+
+	return &schema.Resource{
+		Description: "A certificate authority in the trust store, referenced by its refid.",
+
+		CreateContext: trustCAItem.create,
+		ReadContext:   resourceTrustCARead,
+		UpdateContext: trustCAItem.update,
+		DeleteContext: trustCAItem.delete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceTrustCAImport,
+		},
+
+		CustomizeDiff: resourceTrustCACustomizeDiff,
+
+		Schema: s,
+	}
+}
+
+func resourceTrustCARead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	diags := trustCAItem.read(ctx, d, meta)
+	if diags.HasError() || d.Id() == "" {
+		return diags
+	}
+
+	return setTrustReadyForRenewal(d)
+}
+
+// resourceTrustCAImport guesses the method, CAs with a private key are
+// treated as created by OPNsense.
+func resourceTrustCAImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	id, err := uuid.FromString(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := trustCAItem.get(meta.(*providerMeta), id)
+	if err != nil {
+		return nil, err
+	}
+
+	ca := item.(*trustCA)
+
+	method := trustMethodImport
+	if trustPEM(ca.PrvPayload, ca.Prv) != "" {
+		method = trustMethodCreate
+	}
+
+	err = setResourceData(d, map[string]interface{}{
+		"method":       method,
+		"renew_before": 0,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceTrustCACustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Get("method").(string) == trustMethodImport {
+		if d.NewValueKnown("certificate") && d.Get("certificate").(string) == "" {
+			return fmt.Errorf("certificate is required to import a CA")
+		}
+
+		return nil
+	}
+
+	if d.NewValueKnown("common_name") && d.Get("common_name").(string) == "" {
+		return fmt.Errorf("common_name is required to create a CA")
+	}
+
+	return trustRenewalDiff(d)
+}
+
+// addTrustSchema adds the key, subject and derived attributes shared by
+// CAs and certificates to s.
+func addTrustSchema(s map[string]*schema.Schema) {
+	subject := map[string]string{
+		"common_name":         "Common name of the subject",
+		"country":             "Two letter country code of the subject",
+		"state":               "State or province of the subject",
+		"city":                "City of the subject",
+		"organization":        "Organization of the subject",
+		"organizational_unit": "Organizational unit of the subject",
+		"email":               "Email address of the subject",
+	}
+
+	for k, description := range subject {
+		s[k] = &schema.Schema{
+			Type:             schema.TypeString,
+			Description:      description,
+			Optional:         true,
+			ForceNew:         true,
+			DiffSuppressFunc: suppressTrustImported,
+		}
+	}
+
+	s["key_type"] = &schema.Schema{
+		Type:             schema.TypeString,
+		Description:      "Type of a generated key, e.g. RSA-4096 or prime256v1",
+		Optional:         true,
+		Default:          "RSA-2048",
+		ForceNew:         true,
+		ValidateFunc:     validation.StringInSlice(trustKeyTypes, false),
+		DiffSuppressFunc: suppressTrustImported,
+	}
+	s["digest"] = &schema.Schema{
+		Type:             schema.TypeString,
+		Description:      "Digest used for signing",
+		Optional:         true,
+		Default:          "sha256",
+		ForceNew:         true,
+		ValidateFunc:     validation.StringInSlice(trustDigests, false),
+		DiffSuppressFunc: suppressTrustImported,
+	}
+	s["renew_before"] = &schema.Schema{
+		Type: schema.TypeInt,
+		Description: "Replace a generated certificate when fewer days than this remain, 0 disables renewal. " +
+			"Must be less than lifetime",
+		Optional:     true,
+		Default:      0,
+		ValidateFunc: validation.IntAtLeast(0),
+	}
+	s["certificate"] = &schema.Schema{
+		Type:             schema.TypeString,
+		Description:      "Certificate in PEM format, set to import",
+		Optional:         true,
+		Computed:         true,
+		ForceNew:         true,
+		DiffSuppressFunc: suppressPEMWhitespace,
+	}
+	s["private_key"] = &schema.Schema{
+		Type:             schema.TypeString,
+		Description:      "Private key in PEM format, set to import",
+		Optional:         true,
+		Computed:         true,
+		ForceNew:         true,
+		Sensitive:        true,
+		DiffSuppressFunc: suppressPEMWhitespace,
+	}
+	s["refid"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "Reference ID used by other resources to reference the certificate",
+		Computed:    true,
+	}
+	s["serial"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	s["fingerprint"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "SHA-256 fingerprint of the certificate",
+		Computed:    true,
+	}
+	s["not_after"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "Expiry of the certificate in RFC 3339 format",
+		Computed:    true,
+	}
+	s["ready_for_renewal"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Computed: true,
+	}
+}
+
+func suppressPEMWhitespace(k, old, new string, d *schema.ResourceData) bool {
+	return strings.TrimSpace(old) == strings.TrimSpace(new)
+}
+
+// trustPEM returns the PEM payload, falling back to the base64 encoded
+// PEM stored in the configuration.
+func trustPEM(payload string, encoded string) string {
+	if payload != "" || encoded == "" {
+		return payload
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return ""
+	}
+
+	return string(decoded)
+}
+
+// parseCertificatePEM returns the certificate in certPEM, nil for CSRs and
+// unparsable certificates.
+func parseCertificatePEM(certPEM string) *x509.Certificate {
+	block, _ := pem.Decode([]byte(certPEM))
+	if block == nil {
+		return nil
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil
+	}
+
+	return cert
+}
+
+// flattenCertificatePEM derives serial, fingerprint and expiry from a PEM
+// certificate, they are left empty for CSRs and unparsable certificates.
+// The generation settings are derived as well, so imported certificates
+// match their configuration.
+func flattenCertificatePEM(certPEM string) map[string]interface{} {
+	values := map[string]interface{}{
+		"serial":      "",
+		"fingerprint": "",
+		"not_after":   "",
+	}
+
+	cert := parseCertificatePEM(certPEM)
+	if cert == nil {
+		return values
+	}
+
+	values["serial"] = cert.SerialNumber.String()
+	values["fingerprint"] = fmt.Sprintf("%x", sha256.Sum256(cert.Raw))
+	values["not_after"] = cert.NotAfter.UTC().Format(time.RFC3339)
+	values["lifetime"] = int(math.Round(cert.NotAfter.Sub(cert.NotBefore).Hours() / 24))
+
+	for k, v := range flattenTrustSubject(cert.Subject, cert.PublicKey, cert.SignatureAlgorithm) {
+		values[k] = v
+	}
+
+	return values
+}
+
+// flattenTrustSubject derives the subject, key_type and digest attributes
+// of a certificate or CSR.
+func flattenTrustSubject(subject pkix.Name, key interface{}, algorithm x509.SignatureAlgorithm) map[string]interface{} {
+	first := func(values []string) string {
+		if len(values) == 0 {
+			return ""
+		}
+
+		return values[0]
+	}
+
+	email := ""
+
+	for _, name := range subject.Names {
+		if name.Type.Equal(oidEmailAddress) {
+			email = fmt.Sprint(name.Value)
+		}
+	}
+
+	return map[string]interface{}{
+		"common_name":         subject.CommonName,
+		"country":             first(subject.Country),
+		"state":               first(subject.Province),
+		"city":                first(subject.Locality),
+		"organization":        first(subject.Organization),
+		"organizational_unit": first(subject.OrganizationalUnit),
+		"email":               email,
+		"key_type":            trustKeyType(key),
+		"digest":              trustDigest(algorithm),
+	}
+}
+
+var oidEmailAddress = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 1}
+
+// trustKeyType returns the key_type of a public key, e.g. RSA-2048.
+func trustKeyType(key interface{}) string {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return fmt.Sprintf("RSA-%d", k.N.BitLen())
+	case *ecdsa.PublicKey:
+		curves := map[string]string{
+			"P-256": "prime256v1",
+			"P-384": "secp384r1",
+			"P-521": "secp521r1",
+		}
+
+		return curves[k.Curve.Params().Name]
+	default:
+		return ""
+	}
+}
+
+// trustDigest returns the digest of a signature algorithm, e.g. sha256.
+func trustDigest(algorithm x509.SignatureAlgorithm) string {
+	switch algorithm {
+	case x509.SHA256WithRSA, x509.SHA256WithRSAPSS, x509.ECDSAWithSHA256:
+		return "sha256"
+	case x509.SHA384WithRSA, x509.SHA384WithRSAPSS, x509.ECDSAWithSHA384:
+		return "sha384"
+	case x509.SHA512WithRSA, x509.SHA512WithRSAPSS, x509.ECDSAWithSHA512:
+		return "sha512"
+	default:
+		return strings.ToLower(algorithm.String())
+	}
+}
+
+// suppressTrustImported ignores the generation settings of imported
+// certificates, they are derived from the certificate on read.
+func suppressTrustImported(k, old, new string, d *schema.ResourceData) bool {
+	return d.Id() != "" && d.Get("method").(string) == trustMethodImport
+}
+
+// suppressTrustMethod ignores method once the certificate exists, it is
+// guessed when importing and only matters for creating the certificate.
+func suppressTrustMethod(k, old, new string, d *schema.ResourceData) bool {
+	return d.Id() != ""
+}
+
+func trustRenewalDue(notAfter string, renewBefore int) bool {
+	expiry, err := time.Parse(time.RFC3339, notAfter)
+	if renewBefore == 0 || err != nil {
+		return false
+	}
+
+	return time.Now().Add(time.Duration(renewBefore) * 24 * time.Hour).After(expiry)
+}
+
+func setTrustReadyForRenewal(d *schema.ResourceData) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	ready := d.Get("method").(string) != trustMethodImport &&
+		trustRenewalDue(d.Get("not_after").(string), d.Get("renew_before").(int))
+
+	err := d.Set("ready_for_renewal", ready)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// trustRenewalDiff replaces a generated certificate once fewer than
+// renew_before days remain. The planned renew_before is used, the
+// ready_for_renewal read from state is based on the previous one.
+func trustRenewalDiff(d *schema.ResourceDiff) error {
+	renewBefore := d.Get("renew_before").(int)
+	lifetime := d.Get("lifetime").(int)
+
+	// A replacement would be due as soon as it is created
+	if renewBefore > 0 && d.NewValueKnown("lifetime") && renewBefore >= lifetime {
+		return fmt.Errorf("renew_before %d must be less than lifetime %d", renewBefore, lifetime)
+	}
+
+	if !trustRenewalDue(d.Get("not_after").(string), renewBefore) {
+		return nil
+	}
+
+	// The replacement has a new expiry
+	err := d.SetNewComputed("not_after")
+	if err != nil {
+		return err
+	}
+
+	return d.ForceNew("not_after")
+}
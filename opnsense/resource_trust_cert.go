@@ -0,0 +1,336 @@
+package opnsense
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+// trustCertActions maps the methods of the resource to the OPNsense actions.
+var trustCertActions = map[string]string{
+	trustMethodImport: "import",
+	trustMethodSign:   "internal",
+	trustMethodCSR:    "external",
+}
+
+type trustCert struct {
+	RefID              string    `json:"refid,omitempty"`
+	Description        string    `json:"descr"`
+	Action             mvcOption `json:"action,omitempty"`
+	CARef              mvcOption `json:"caref,omitempty"`
+	CertType           mvcOption `json:"cert_type,omitempty"`
+	KeyType            mvcOption `json:"key_type,omitempty"`
+	Digest             mvcOption `json:"digest,omitempty"`
+	Lifetime           mvcInt    `json:"lifetime,omitempty"`
+	Country            mvcOption `json:"country,omitempty"`
+	State              string    `json:"state,omitempty"`
+	City               string    `json:"city,omitempty"`
+	Organization       string    `json:"organization,omitempty"`
+	OrganizationalUnit string    `json:"organizationalunit,omitempty"`
+	Email              string    `json:"email,omitempty"`
+	CommonName         string    `json:"commonname,omitempty"`
+	AltNamesDNS        string    `json:"altnames_dns,omitempty"`
+	AltNamesIP         string    `json:"altnames_ip,omitempty"`
+	Crt                string    `json:"crt,omitempty"`
+	Csr                string    `json:"csr,omitempty"`
+	Prv                string    `json:"prv,omitempty"`
+	CrtPayload         string    `json:"crt_payload,omitempty"`
+	CsrPayload         string    `json:"csr_payload,omitempty"`
+	PrvPayload         string    `json:"prv_payload,omitempty"`
+}
+
+var trustCertItem = &mvcItem{
+	controller: "trust/cert",
+	key:        "cert",
+
+	newItem: func() interface{} { return &trustCert{} },
+	expand: func(d *schema.ResourceData) (interface{}, error) {
+		// Everything but the description is fixed once the certificate exists
+		if !d.IsNewResource() {
+			return &trustCert{Description: d.Get("description").(string)}, nil
+		}
+
+		method := d.Get("method").(string)
+
+		cert := &trustCert{
+			Description: d.Get("description").(string),
+			Action:      mvcOption(trustCertActions[method]),
+		}
+
+		if method == trustMethodImport {
+			cert.CrtPayload = d.Get("certificate").(string)
+			cert.PrvPayload = d.Get("private_key").(string)
+
+			return cert, nil
+		}
+
+		cert.CARef = mvcOption(d.Get("ca").(string))
+		cert.CertType = mvcOption(d.Get("type").(string))
+		cert.KeyType = mvcOption(d.Get("key_type").(string))
+		cert.Digest = mvcOption(d.Get("digest").(string))
+		cert.Lifetime = mvcInt(d.Get("lifetime").(int))
+		cert.Country = mvcOption(d.Get("country").(string))
+		cert.State = d.Get("state").(string)
+		cert.City = d.Get("city").(string)
+		cert.Organization = d.Get("organization").(string)
+		cert.OrganizationalUnit = d.Get("organizational_unit").(string)
+		cert.Email = d.Get("email").(string)
+		cert.CommonName = d.Get("common_name").(string)
+		// Subject alternative names are stored one per line
+		cert.AltNamesDNS = strings.Join(expandStringList(d.Get("dns_names").([]interface{})), "\n")
+		cert.AltNamesIP = strings.Join(expandStringList(d.Get("ip_addresses").([]interface{})), "\n")
+
+		return cert, nil
+	},
+	flatten: func(item interface{}) map[string]interface{} {
+		cert := item.(*trustCert)
+
+		values := map[string]interface{}{
+			"refid":       cert.RefID,
+			"description": cert.Description,
+			"certificate": trustPEM(cert.CrtPayload, cert.Crt),
+			"csr":         trustPEM(cert.CsrPayload, cert.Csr),
+			"private_key": trustPEM(cert.PrvPayload, cert.Prv),
+		}
+
+		for k, v := range flattenCertificatePEM(values["certificate"].(string)) {
+			values[k] = v
+		}
+
+		if crt := parseCertificatePEM(values["certificate"].(string)); crt != nil {
+			values["ca"] = string(cert.CARef)
+			values["type"] = trustCertType(crt)
+			values["dns_names"] = crt.DNSNames
+			values["ip_addresses"] = flattenIPAddresses(crt.IPAddresses)
+		} else if csr := parseCSRPEM(values["csr"].(string)); csr != nil {
+			for k, v := range flattenTrustSubject(csr.Subject, csr.PublicKey, csr.SignatureAlgorithm) {
+				values[k] = v
+			}
+
+			values["dns_names"] = csr.DNSNames
+			values["ip_addresses"] = flattenIPAddresses(csr.IPAddresses)
+		}
+
+		return values
+	},
+}
+
+// trustCertType returns the type of a certificate from its extended key usage.
+func trustCertType(cert *x509.Certificate) string {
+	if cert.IsCA {
+		return "v3_ca"
+	}
+
+	server, client := false, false
+
+	for _, usage := range cert.ExtKeyUsage {
+		server = server || usage == x509.ExtKeyUsageServerAuth
+		client = client || usage == x509.ExtKeyUsageClientAuth
+	}
+
+	switch {
+	case server && client:
+		return "combined_server_client"
+	case server:
+		return "server_cert"
+	case client:
+		return "usr_cert"
+	default:
+		return ""
+	}
+}
+
+// parseCSRPEM returns the signing request in csrPEM, nil when it is unparsable.
+func parseCSRPEM(csrPEM string) *x509.CertificateRequest {
+	block, _ := pem.Decode([]byte(csrPEM))
+	if block == nil {
+		return nil
+	}
+
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil
+	}
+
+	return csr
+}
+
+func flattenIPAddresses(ips []net.IP) []string {
+	addresses := make([]string, len(ips))
+
+	for index, ip := range ips {
+		addresses[index] = ip.String()
+	}
+
+	return addresses
+}
+
+func resourceTrustCert() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"method": {
+			Type: schema.TypeString,
+			Description: "sign to create a certificate signed by an internal CA, import to import " +
+				"an existing one or csr to create a signing request for an external CA",
+			Optional:         true,
+			Default:          trustMethodSign,
+			ForceNew:         true,
+			ValidateFunc:     validation.StringInSlice([]string{trustMethodSign, trustMethodImport, trustMethodCSR}, false),
+			DiffSuppressFunc: suppressTrustMethod,
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Description: "Description of the certificate",
+			Required:    true,
+		},
+		"ca": {
+			Type:             schema.TypeString,
+			Description:      "refid of the opnsense_trust_ca signing the certificate",
+			Optional:         true,
+			ForceNew:         true,
+			DiffSuppressFunc: suppressTrustImported,
+		},
+		"type": {
+			Type:             schema.TypeString,
+			Description:      "Usage of the certificate",
+			Optional:         true,
+			Default:          "server_cert",
+			ForceNew:         true,
+			DiffSuppressFunc: suppressTrustImported,
+			ValidateFunc: validation.StringInSlice([]string{
+				"usr_cert", "server_cert", "combined_server_client", "v3_ca",
+			}, false),
+		},
+		"lifetime": {
+			Type:             schema.TypeInt,
+			Description:      "Lifetime of a signed certificate in days",
+			Optional:         true,
+			Default:          397,
+			ForceNew:         true,
+			ValidateFunc:     validation.IntAtLeast(1),
+			DiffSuppressFunc: suppressTrustImported,
+		},
+		"dns_names": {
+			Type:             schema.TypeList,
+			Description:      "DNS subject alternative names, empty uses the names OPNsense adds",
+			Optional:         true,
+			Computed:         true,
+			ForceNew:         true,
+			DiffSuppressFunc: suppressTrustImported,
+			Elem:             &schema.Schema{Type: schema.TypeString},
+		},
+		"ip_addresses": {
+			Type:             schema.TypeList,
+			Description:      "IP address subject alternative names, empty uses the names OPNsense adds",
+			Optional:         true,
+			Computed:         true,
+			ForceNew:         true,
+			DiffSuppressFunc: suppressTrustImported,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.IsIPAddress,
+			},
+		},
+		"csr": {
+			Type:        schema.TypeString,
+			Description: "Signing request in PEM format when method is csr",
+			Computed:    true,
+		},
+	}
+
+	addTrustSchema(s)
+
+	return &schema.Resource{
+		Description: "A certificate in the trust store, referenced by its refid.",
+
+		CreateContext: trustCertItem.create,
+		ReadContext:   resourceTrustCertRead,
+		UpdateContext: trustCertItem.update,
+		DeleteContext: trustCertItem.delete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceTrustCertImport,
+		},
+
+		CustomizeDiff: resourceTrustCertCustomizeDiff,
+
+		Schema: s,
+	}
+}
+
+func resourceTrustCertRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	diags := trustCertItem.read(ctx, d, meta)
+	if diags.HasError() || d.Id() == "" {
+		return diags
+	}
+
+	return setTrustReadyForRenewal(d)
+}
+
+// resourceTrustCertImport guesses the method from what OPNsense stores, a
+// certificate with a private key and a CA on the OPNsense is treated as
+// signed by it.
+func resourceTrustCertImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	id, err := uuid.FromString(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := trustCertItem.get(meta.(*providerMeta), id)
+	if err != nil {
+		return nil, err
+	}
+
+	cert := item.(*trustCert)
+
+	values := map[string]interface{}{
+		"method":       trustMethodImport,
+		"renew_before": 0,
+	}
+
+	switch {
+	case trustPEM(cert.CrtPayload, cert.Crt) == "" && trustPEM(cert.CsrPayload, cert.Csr) != "":
+		values["method"] = trustMethodCSR
+		// Not part of a signing request
+		values["type"] = "server_cert"
+		values["lifetime"] = 397
+	case cert.CARef != "" && trustPEM(cert.PrvPayload, cert.Prv) != "":
+		values["method"] = trustMethodSign
+	}
+
+	err = setResourceData(d, values)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceTrustCertCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	method := d.Get("method").(string)
+
+	if method == trustMethodImport {
+		if d.NewValueKnown("certificate") && d.Get("certificate").(string) == "" {
+			return fmt.Errorf("certificate is required to import a certificate")
+		}
+
+		return nil
+	}
+
+	if d.NewValueKnown("common_name") && d.Get("common_name").(string) == "" {
+		return fmt.Errorf("common_name is required when method is %s", method)
+	}
+
+	if method == trustMethodSign && d.NewValueKnown("ca") && d.Get("ca").(string) == "" {
+		return fmt.Errorf("ca is required when method is %s", method)
+	}
+
+	return trustRenewalDiff(d)
+}
@@ -0,0 +1,208 @@
+package opnsense
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestTrustRenewalDiff(t *testing.T) {
+	tests := []struct {
+		remaining   int
+		renewBefore int
+		replace     bool
+		err         bool
+	}{
+		{300, 0, false, false},
+		{300, 30, false, false},
+		{20, 30, true, false},
+		// renew_before is raised beyond the remaining lifetime
+		{100, 200, true, false},
+		{300, 397, false, true},
+	}
+
+	for _, test := range tests {
+		state := &terraform.InstanceState{
+			ID: "1ab2c3d4-0000-4000-8000-000000000000",
+			Attributes: map[string]string{
+				"method":            trustMethodSign,
+				"description":       "server",
+				"ca":                "1ab2c3d4e5f6a",
+				"common_name":       "vpn.example.com",
+				"lifetime":          "397",
+				"renew_before":      "30",
+				"ready_for_renewal": "false",
+				"not_after":         time.Now().Add(time.Duration(test.remaining) * 24 * time.Hour).Format(time.RFC3339),
+			},
+		}
+
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"description":  "server",
+			"ca":           "1ab2c3d4e5f6a",
+			"common_name":  "vpn.example.com",
+			"renew_before": test.renewBefore,
+		})
+
+		diff, err := resourceTrustCert().SimpleDiff(context.Background(), state, config, nil)
+		if test.err {
+			if err == nil {
+				t.Errorf("renew_before %d: expected an error", test.renewBefore)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("renew_before %d: %s", test.renewBefore, err)
+		}
+
+		attr, ok := diff.Attributes["not_after"]
+		if replace := ok && attr.RequiresNew; replace != test.replace {
+			t.Errorf("%d days remaining, renew_before %d: replace = %t, want %t",
+				test.remaining, test.renewBefore, replace, test.replace)
+		}
+	}
+}
+
+func testTrustCertResource(name string, renewBefore int) string {
+	return fmt.Sprintf(`
+resource "opnsense_trust_ca" "%s" {
+  description = "%s"
+  common_name = "%s CA"
+  key_type    = "prime256v1"
+}
+
+resource "opnsense_trust_cert" "%s_server" {
+  description  = "%s server"
+  ca           = opnsense_trust_ca.%s.refid
+  common_name  = "vpn.example.com"
+  dns_names    = ["vpn.example.com"]
+  renew_before = %d
+}
+
+resource "opnsense_trust_cert" "%s_user" {
+  description = "%s user"
+  ca          = opnsense_trust_ca.%s.refid
+  type        = "usr_cert"
+  common_name = "%s"
+}
+
+resource "opnsense_openvpn_instance" "%s" {
+  role           = "server"
+  port           = 1195
+  tunnel_network = "10.8.0.0/24"
+  certificate    = opnsense_trust_cert.%s_server.refid
+  ca             = opnsense_trust_ca.%s.refid
+  description    = "%s"
+}
+
+data "opnsense_openvpn_client_export" "%s" {
+  vpn_id      = opnsense_openvpn_instance.%s.vpn_id
+  certificate = opnsense_trust_cert.%s_user.refid
+  hostname    = "vpn.example.com"
+}
+`, name, name, name,
+		name, name, name, renewBefore,
+		name, name, name, name,
+		name, name, name, name,
+		name, name, name)
+}
+
+func testAccTrustCertResourceDestroy(s *terraform.State) error {
+	items := map[string]*mvcItem{
+		"opnsense_trust_ca":         trustCAItem,
+		"opnsense_trust_cert":       trustCertItem,
+		"opnsense_openvpn_instance": openVPNInstanceItem,
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		item, ok := items[rs.Type]
+		if !ok {
+			continue
+		}
+
+		err := testAccCheckMvcItemDestroyed(item, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestTrustCert_openVPNExport(t *testing.T) {
+	rName := fmt.Sprintf("a%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccTrustCertResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testTrustCertResource(rName, 0),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(
+						fmt.Sprintf("opnsense_trust_ca.%s", rName),
+						"fingerprint",
+						regexp.MustCompile("^[0-9a-f]{64}$"),
+					),
+					resource.TestCheckResourceAttrSet(
+						fmt.Sprintf("opnsense_trust_cert.%s_server", rName),
+						"not_after",
+					),
+					resource.TestCheckResourceAttr(
+						fmt.Sprintf("opnsense_trust_cert.%s_server", rName),
+						"ready_for_renewal",
+						"false",
+					),
+					resource.TestMatchResourceAttr(
+						fmt.Sprintf("data.opnsense_openvpn_client_export.%s", rName),
+						"content",
+						regexp.MustCompile("remote vpn.example.com 1195"),
+					),
+				),
+			},
+			{
+				ResourceName:      fmt.Sprintf("opnsense_trust_ca.%s", rName),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      fmt.Sprintf("opnsense_trust_cert.%s_server", rName),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      fmt.Sprintf("opnsense_trust_cert.%s_user", rName),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testTrustCertResource(rName, 30),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						fmt.Sprintf("opnsense_trust_cert.%s_server", rName),
+						"ready_for_renewal",
+						"false",
+					),
+				),
+			},
+			{
+				// The generation settings read back match the configuration
+				Config:   testTrustCertResource(rName, 30),
+				PlanOnly: true,
+			},
+			{
+				Config:      testTrustCertResource(rName, 397),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("renew_before 397 must be less than lifetime 397"),
+			},
+		},
+	})
+}
@@ -0,0 +1,352 @@
+package opnsense
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/kradalby/opnsense-go/opnsense"
+	uuid "github.com/satori/go.uuid"
+)
+
+// crlReasons maps revocation reasons to their RFC 5280 code, OPNsense
+// stores the revoked certificates of each reason in revoked_reason_<code>.
+var crlReasons = map[string]int{
+	"unspecified":            0,
+	"key_compromise":         1,
+	"ca_compromise":          2,
+	"affiliation_changed":    3,
+	"superseded":             4,
+	"cessation_of_operation": 5,
+	"certificate_hold":       6,
+}
+
+type trustCertRow struct {
+	UUID  string `json:"uuid"`
+	RefID string `json:"refid"`
+}
+
+func resourceTrustCRL() *schema.Resource {
+	reasons := make([]string, 0, len(crlReasons))
+	for reason := range crlReasons {
+		reasons = append(reasons, reason)
+	}
+
+	return &schema.Resource{
+		Description: "The revocation list of an internal opnsense_trust_ca, there is only one per CA.",
+
+		CreateContext: resourceTrustCRLUpdate,
+		ReadContext:   resourceTrustCRLRead,
+		UpdateContext: resourceTrustCRLUpdate,
+		DeleteContext: resourceTrustCRLDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		CustomizeDiff: resourceTrustCRLCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"ca": {
+				Type:        schema.TypeString,
+				Description: "refid of the opnsense_trust_ca",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "Description of the revocation list",
+				Required:    true,
+			},
+			"lifetime": {
+				Type:         schema.TypeInt,
+				Description:  "Days until the next update of the revocation list",
+				Optional:     true,
+				Default:      9999,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"revoked": {
+				Type:        schema.TypeSet,
+				Description: "Revoked certificates",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"certificate": {
+							Type:        schema.TypeString,
+							Description: "refid of the revoked opnsense_trust_cert",
+							Required:    true,
+						},
+						"reason": {
+							Type:         schema.TypeString,
+							Description:  "Reason of the revocation, e.g. key_compromise or superseded",
+							Optional:     true,
+							Default:      "unspecified",
+							ValidateFunc: validation.StringInSlice(reasons, false),
+						},
+					},
+				},
+			},
+			"pem": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"serial": {
+				Type:        schema.TypeString,
+				Description: "CRL number of the current revocation list",
+				Computed:    true,
+			},
+			"next_update": {
+				Type:        schema.TypeString,
+				Description: "Next update of the revocation list in RFC 3339 format",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceTrustCRLCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if meta == nil || !d.NewValueKnown("ca") || !d.NewValueKnown("revoked") {
+		return nil
+	}
+
+	revoked := d.Get("revoked").(*schema.Set).List()
+	if len(revoked) == 0 {
+		return nil
+	}
+
+	m := meta.(*providerMeta)
+	ca := d.Get("ca").(string)
+
+	rows := []trustCertRow{}
+
+	err := trustCertItem.search(m, &rows)
+	if err != nil {
+		return err
+	}
+
+	certs := map[string]string{}
+	for _, row := range rows {
+		certs[row.RefID] = row.UUID
+	}
+
+	for _, raw := range revoked {
+		refID := raw.(map[string]interface{})["certificate"].(string)
+
+		// Unknown until apply, e.g. when the certificate is created in the same apply
+		if refID == "" {
+			continue
+		}
+
+		id, err := uuid.FromString(certs[refID])
+		if err != nil {
+			return fmt.Errorf("certificate %s: %w", refID, ErrNotFound)
+		}
+
+		item, err := trustCertItem.get(m, id)
+		if err != nil {
+			return err
+		}
+
+		if string(item.(*trustCert).CARef) != ca {
+			return fmt.Errorf("certificate %s is not issued by CA %s", refID, ca)
+		}
+	}
+
+	return nil
+}
+
+func resourceTrustCRLRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[TRACE] Getting OPNsense client from meta")
+
+	var diags diag.Diagnostics
+
+	c := meta.(*providerMeta).client
+
+	crl, err := trustCRLGet(c, d.Id())
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			d.SetId("")
+
+			return diags
+		}
+
+		log.Printf("[ERROR] Failed to fetch CRL of %s", d.Id())
+
+		return diag.FromErr(err)
+	}
+
+	values := map[string]interface{}{
+		"ca":          d.Id(),
+		"description": crl.Description,
+		"lifetime":    crl.Lifetime,
+		"revoked":     crl.Revoked,
+		"pem":         crl.PEM,
+		"serial":      crl.Serial,
+		"next_update": crl.NextUpdate,
+	}
+
+	err = setResourceData(d, values)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceTrustCRLUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*providerMeta).client
+
+	ca := d.Get("ca").(string)
+
+	crl := map[string]interface{}{
+		"descr":     d.Get("description").(string),
+		"crlmethod": "internal",
+		"lifetime":  mvcInt(d.Get("lifetime").(int)),
+	}
+
+	// Every reason is sent so certificates removed from a reason are unrevoked
+	revoked := map[int]mvcList{}
+
+	for _, raw := range d.Get("revoked").(*schema.Set).List() {
+		r := raw.(map[string]interface{})
+		code := crlReasons[r["reason"].(string)]
+		revoked[code] = append(revoked[code], r["certificate"].(string))
+	}
+
+	for _, code := range crlReasons {
+		crl[fmt.Sprintf("revoked_reason_%d", code)] = revoked[code]
+	}
+
+	err := mvcSet(c, "trust/crl/set/"+ca, "crl", crl)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(ca)
+
+	return resourceTrustCRLRead(ctx, d, meta)
+}
+
+func resourceTrustCRLDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*providerMeta).client
+
+	var diags diag.Diagnostics
+
+	err := mvcDelete(c, "trust/crl/del/"+d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+type trustCRL struct {
+	Description string
+	Lifetime    int
+	Revoked     []map[string]interface{}
+	PEM         string
+	Serial      string
+	ThisUpdate  string
+	NextUpdate  string
+	// RevokedSerials are the serials in the current revocation list
+	RevokedSerials []string
+}
+
+// trustCRLGet fetches the revocation list of the CA with the given refid
+// and parses the current list.
+func trustCRLGet(c *opnsense.Client, ca string) (*trustCRL, error) {
+	raw := map[string]json.RawMessage{}
+
+	err := mvcGet(c, "trust/crl/get/"+ca, "crl", &raw)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := struct {
+		Description string `json:"descr"`
+		Lifetime    mvcInt `json:"lifetime"`
+		Text        string `json:"text"`
+	}{}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, &fields)
+	if err != nil {
+		return nil, err
+	}
+
+	// OPNsense returns an empty form for CAs without revocation list
+	if fields.Description == "" {
+		return nil, fmt.Errorf("CRL of %s: %w", ca, ErrNotFound)
+	}
+
+	crl := &trustCRL{
+		Description: fields.Description,
+		Lifetime:    int(fields.Lifetime),
+		Revoked:     []map[string]interface{}{},
+		PEM:         fields.Text,
+	}
+
+	if !strings.Contains(crl.PEM, "-----BEGIN") {
+		crl.PEM = trustPEM("", fields.Text)
+	}
+
+	for reason, code := range crlReasons {
+		certs := mvcList{}
+
+		if data, ok := raw[fmt.Sprintf("revoked_reason_%d", code)]; ok {
+			err = json.Unmarshal(data, &certs)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for _, cert := range certs {
+			crl.Revoked = append(crl.Revoked, map[string]interface{}{
+				"certificate": cert,
+				"reason":      reason,
+			})
+		}
+	}
+
+	if crl.PEM == "" {
+		return crl, nil
+	}
+
+	block, _ := pem.Decode([]byte(crl.PEM))
+	if block == nil {
+		return nil, fmt.Errorf("CRL of %s is not in PEM format", ca)
+	}
+
+	list, err := x509.ParseRevocationList(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	crl.ThisUpdate = list.ThisUpdate.UTC().Format(time.RFC3339)
+	crl.NextUpdate = list.NextUpdate.UTC().Format(time.RFC3339)
+
+	if list.Number != nil {
+		crl.Serial = list.Number.String()
+	}
+
+	for _, entry := range list.RevokedCertificateEntries {
+		crl.RevokedSerials = append(crl.RevokedSerials, entry.SerialNumber.String())
+	}
+
+	return crl, nil
+}
@@ -0,0 +1,115 @@
+package opnsense
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testTrustCRLResource(name string, revoked string) string {
+	return fmt.Sprintf(`
+resource "opnsense_trust_ca" "%s" {
+  description = "%s"
+  common_name = "%s CA"
+}
+
+resource "opnsense_trust_ca" "%s_other" {
+  description = "%s other"
+  common_name = "%s other CA"
+}
+
+resource "opnsense_trust_cert" "%s" {
+  description = "%s"
+  ca          = opnsense_trust_ca.%s.refid
+  type        = "usr_cert"
+  common_name = "%s"
+}
+
+resource "opnsense_trust_cert" "%s_other" {
+  description = "%s other"
+  ca          = opnsense_trust_ca.%s_other.refid
+  type        = "usr_cert"
+  common_name = "%s other"
+}
+
+resource "opnsense_trust_crl" "%s" {
+  ca          = opnsense_trust_ca.%s.refid
+  description = "%s"
+
+  revoked {
+    certificate = opnsense_trust_cert.%s.refid
+    reason      = "key_compromise"
+  }
+}
+
+data "opnsense_trust_crl" "%s" {
+  ca = opnsense_trust_crl.%s.ca
+}
+`, name, name, name,
+		name, name, name,
+		name, name, name, name,
+		name, name, name, name,
+		name, name, name, revoked,
+		name, name)
+}
+
+func testAccTrustCRLResourceDestroy(s *terraform.State) error {
+	items := map[string]*mvcItem{
+		"opnsense_trust_ca":   trustCAItem,
+		"opnsense_trust_cert": trustCertItem,
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		item, ok := items[rs.Type]
+		if !ok {
+			continue
+		}
+
+		err := testAccCheckMvcItemDestroyed(item, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestTrustCRL_revoke(t *testing.T) {
+	rName := fmt.Sprintf("a%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccTrustCRLResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testTrustCRLResource(rName, rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						fmt.Sprintf("opnsense_trust_crl.%s", rName),
+						"revoked.#",
+						"1",
+					),
+					resource.TestCheckResourceAttrPair(
+						fmt.Sprintf("data.opnsense_trust_crl.%s", rName),
+						"revoked_serials.0",
+						fmt.Sprintf("opnsense_trust_cert.%s", rName),
+						"serial",
+					),
+					resource.TestCheckResourceAttrSet(
+						fmt.Sprintf("data.opnsense_trust_crl.%s", rName),
+						"next_update",
+					),
+				),
+			},
+			{
+				Config:      testTrustCRLResource(rName, rName+"_other"),
+				ExpectError: regexp.MustCompile("is not issued by CA"),
+			},
+		},
+	})
+}
@@ -0,0 +1,95 @@
+package opnsense
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type unboundACL struct {
+	Enabled     mvcBool   `json:"enabled"`
+	Name        string    `json:"name"`
+	Action      mvcOption `json:"action"`
+	Networks    mvcList   `json:"networks"`
+	Description string    `json:"description"`
+}
+
+var unboundACLItem = &mvcItem{
+	controller:  "unbound/settings",
+	name:        "Acl",
+	key:         "acl",
+	reconfigure: unboundReconfigure,
+
+	newItem: func() interface{} { return &unboundACL{} },
+	expand: func(d *schema.ResourceData) (interface{}, error) {
+		return &unboundACL{
+			Enabled:     mvcBool(d.Get("enabled").(bool)),
+			Name:        d.Get("name").(string),
+			Action:      mvcOption(d.Get("action").(string)),
+			Networks:    expandStringSet(d.Get("networks").(*schema.Set)),
+			Description: d.Get("description").(string),
+		}, nil
+	},
+	flatten: func(item interface{}) map[string]interface{} {
+		acl := item.(*unboundACL)
+
+		return map[string]interface{}{
+			"enabled":     bool(acl.Enabled),
+			"name":        acl.Name,
+			"action":      string(acl.Action),
+			"networks":    []string(acl.Networks),
+			"description": acl.Description,
+		}
+	},
+}
+
+func resourceUnboundACL() *schema.Resource {
+	return &schema.Resource{
+		Description: "An access list controlling which networks may query Unbound.",
+
+		CreateContext: unboundACLItem.create,
+		ReadContext:   unboundACLItem.read,
+		UpdateContext: unboundACLItem.update,
+		DeleteContext: unboundACLItem.delete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Description: "Enable the access list",
+				Optional:    true,
+				Default:     true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "Name of the access list",
+				Required:    true,
+			},
+			"action": {
+				Type:        schema.TypeString,
+				Description: "Action for queries from the networks",
+				Required:    true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"allow", "deny", "refuse", "allow_snoop", "deny_nonlocal", "refuse_nonlocal",
+				}, false),
+			},
+			"networks": {
+				Type:        schema.TypeSet,
+				Description: "Networks the action applies to",
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsCIDRNetwork(0, 128),
+				},
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "Description of the access list",
+				Optional:    true,
+			},
+		},
+	}
+}
@@ -0,0 +1,194 @@
+package opnsense
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const unboundDNSBLID = "unbound_dnsbl"
+
+type unboundDNSBL struct {
+	Enabled    mvcBool `json:"enabled"`
+	SafeSearch mvcBool `json:"safesearch"`
+	Type       mvcList `json:"type"`
+	Lists      mvcList `json:"lists"`
+	Whitelists mvcList `json:"whitelists"`
+	Blocklists mvcList `json:"blocklists"`
+	Wildcards  mvcList `json:"wildcards"`
+	Address    string  `json:"address"`
+	NXDomain   mvcBool `json:"nxdomain"`
+}
+
+type unboundSettings struct {
+	DNSBL unboundDNSBL `json:"dnsbl"`
+}
+
+func resourceUnboundDNSBL() *schema.Resource {
+	return &schema.Resource{
+		Description: "DNS blocklist settings of Unbound. There is only one per OPNsense, " +
+			"destroying the resource disables and clears the blocklist.",
+
+		CreateContext: resourceUnboundDNSBLUpdate,
+		ReadContext:   resourceUnboundDNSBLRead,
+		UpdateContext: resourceUnboundDNSBLUpdate,
+		DeleteContext: resourceUnboundDNSBLDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Description: "Enable the blocklist",
+				Required:    true,
+			},
+			"providers": {
+				Type:        schema.TypeSet,
+				Description: "Predefined blocklist providers, e.g. atf for Abuse.ch ThreatFox or sa for StevenBlack",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"custom_urls": {
+				Type:        schema.TypeSet,
+				Description: "URLs of additional blocklists",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				},
+			},
+			"whitelist": {
+				Type:        schema.TypeSet,
+				Description: "Domains never blocked, regular expressions are allowed",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"blocklist": {
+				Type:        schema.TypeSet,
+				Description: "Domains always blocked, regular expressions are allowed",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"wildcards": {
+				Type:        schema.TypeSet,
+				Description: "Domains blocked including all subdomains",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"safesearch": {
+				Type:        schema.TypeBool,
+				Description: "Force safe search on search engines",
+				Optional:    true,
+				Default:     false,
+			},
+			"address": {
+				Type:         schema.TypeString,
+				Description:  "Address blocked domains resolve to, empty uses 0.0.0.0",
+				Optional:     true,
+				ValidateFunc: validation.Any(validation.StringIsEmpty, validation.IsIPAddress),
+			},
+			"nxdomain": {
+				Type:        schema.TypeBool,
+				Description: "Answer blocked domains with NXDOMAIN instead of an address",
+				Optional:    true,
+				Default:     false,
+			},
+		},
+	}
+}
+
+func resourceUnboundDNSBLRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[TRACE] Getting OPNsense client from meta")
+
+	var diags diag.Diagnostics
+
+	c := meta.(*providerMeta).client
+
+	settings := unboundSettings{}
+
+	err := mvcGet(c, "unbound/settings/get", "unbound", &settings)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch Unbound settings")
+
+		return diag.FromErr(err)
+	}
+
+	dnsbl := settings.DNSBL
+
+	values := map[string]interface{}{
+		"enabled":     bool(dnsbl.Enabled),
+		"providers":   []string(dnsbl.Type),
+		"custom_urls": []string(dnsbl.Lists),
+		"whitelist":   []string(dnsbl.Whitelists),
+		"blocklist":   []string(dnsbl.Blocklists),
+		"wildcards":   []string(dnsbl.Wildcards),
+		"safesearch":  bool(dnsbl.SafeSearch),
+		"address":     dnsbl.Address,
+		"nxdomain":    bool(dnsbl.NXDomain),
+	}
+
+	err = setResourceData(d, values)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(unboundDNSBLID)
+
+	return diags
+}
+
+func resourceUnboundDNSBLUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	dnsbl := unboundDNSBL{
+		Enabled:    mvcBool(d.Get("enabled").(bool)),
+		Type:       expandStringSet(d.Get("providers").(*schema.Set)),
+		Lists:      expandStringSet(d.Get("custom_urls").(*schema.Set)),
+		Whitelists: expandStringSet(d.Get("whitelist").(*schema.Set)),
+		Blocklists: expandStringSet(d.Get("blocklist").(*schema.Set)),
+		Wildcards:  expandStringSet(d.Get("wildcards").(*schema.Set)),
+		SafeSearch: mvcBool(d.Get("safesearch").(bool)),
+		Address:    d.Get("address").(string),
+		NXDomain:   mvcBool(d.Get("nxdomain").(bool)),
+	}
+
+	err := setUnboundDNSBL(meta.(*providerMeta), dnsbl)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceUnboundDNSBLRead(ctx, d, meta)
+}
+
+func resourceUnboundDNSBLDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	err := setUnboundDNSBL(meta.(*providerMeta), unboundDNSBL{})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// setUnboundDNSBL saves the blocklist settings and downloads the lists.
+func setUnboundDNSBL(meta *providerMeta, dnsbl unboundDNSBL) error {
+	err := mvcSet(meta.client, "unbound/settings/set", "unbound", unboundSettings{DNSBL: dnsbl})
+	if err != nil {
+		return err
+	}
+
+	err = unboundReconfigure(meta)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[TRACE] Updating Unbound blocklists")
+
+	return mvcAction(meta.client, "unbound/service/dnsbl")
+}
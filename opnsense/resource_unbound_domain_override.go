@@ -0,0 +1,111 @@
+package opnsense
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type unboundDomainOverride struct {
+	Enabled     mvcBool `json:"enabled"`
+	Domain      string  `json:"domain"`
+	Server      string  `json:"server"`
+	Description string  `json:"description"`
+}
+
+var unboundDomainOverrideItem = &mvcItem{
+	controller:  "unbound/settings",
+	name:        "DomainOverride",
+	key:         "domain",
+	reconfigure: unboundReconfigure,
+
+	newItem: func() interface{} { return &unboundDomainOverride{} },
+	expand: func(d *schema.ResourceData) (interface{}, error) {
+		return &unboundDomainOverride{
+			Enabled:     mvcBool(d.Get("enabled").(bool)),
+			Domain:      d.Get("domain").(string),
+			Server:      d.Get("server").(string),
+			Description: d.Get("description").(string),
+		}, nil
+	},
+	flatten: func(item interface{}) map[string]interface{} {
+		domain := item.(*unboundDomainOverride)
+
+		return map[string]interface{}{
+			"enabled":     bool(domain.Enabled),
+			"domain":      domain.Domain,
+			"server":      domain.Server,
+			"description": domain.Description,
+		}
+	},
+}
+
+func resourceUnboundDomainOverride() *schema.Resource {
+	return &schema.Resource{
+		Description: "Forwards queries for a domain to another DNS server. Unbound is reconfigured " +
+			"once for all Unbound changes made within 3 seconds of each other, see the README.",
+
+		CreateContext: unboundDomainOverrideItem.create,
+		ReadContext:   unboundDomainOverrideItem.read,
+		UpdateContext: unboundDomainOverrideItem.update,
+		DeleteContext: unboundDomainOverrideItem.delete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Description: "Enable the override",
+				Optional:    true,
+				Default:     true,
+			},
+			"domain": {
+				Type:        schema.TypeString,
+				Description: "Domain to forward, e.g. corp.example.com",
+				Required:    true,
+			},
+			"server": {
+				Type:         schema.TypeString,
+				Description:  "Address of the DNS server, optionally with a port, e.g. 10.0.0.53@5353",
+				Required:     true,
+				ValidateFunc: validateDNSServer,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "Description of the override",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// validateDNSServer accepts an IP address with an optional @port suffix.
+func validateDNSServer(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("%s: %w", k, ErrExpectedString)}
+	}
+
+	address, port := v, ""
+	if index := strings.LastIndex(v, "@"); index >= 0 {
+		address, port = v[:index], v[index+1:]
+	}
+
+	if net.ParseIP(address) == nil {
+		return nil, []error{fmt.Errorf("%s: %q is not an IP address", k, address)}
+	}
+
+	if port != "" {
+		n, err := strconv.Atoi(port)
+		if err != nil || n < 1 || n > 65535 {
+			return nil, []error{fmt.Errorf("%s: %q is not a valid port", k, port)}
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,107 @@
+package opnsense
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type unboundForward struct {
+	Enabled     mvcBool   `json:"enabled"`
+	Type        mvcOption `json:"type"`
+	Domain      string    `json:"domain"`
+	Server      string    `json:"server"`
+	Port        mvcInt    `json:"port"`
+	Verify      string    `json:"verify"`
+	Description string    `json:"description"`
+}
+
+var unboundForwardItem = &mvcItem{
+	controller:  "unbound/settings",
+	name:        "Forward",
+	key:         "dot",
+	reconfigure: unboundReconfigure,
+
+	newItem: func() interface{} { return &unboundForward{} },
+	expand: func(d *schema.ResourceData) (interface{}, error) {
+		return &unboundForward{
+			Enabled:     mvcBool(d.Get("enabled").(bool)),
+			Type:        mvcOption(d.Get("type").(string)),
+			Domain:      d.Get("domain").(string),
+			Server:      d.Get("server").(string),
+			Port:        mvcInt(d.Get("port").(int)),
+			Verify:      d.Get("verify").(string),
+			Description: d.Get("description").(string),
+		}, nil
+	},
+	flatten: func(item interface{}) map[string]interface{} {
+		forward := item.(*unboundForward)
+
+		return map[string]interface{}{
+			"enabled":     bool(forward.Enabled),
+			"type":        string(forward.Type),
+			"domain":      forward.Domain,
+			"server":      forward.Server,
+			"port":        int(forward.Port),
+			"verify":      forward.Verify,
+			"description": forward.Description,
+		}
+	},
+}
+
+func resourceUnboundForward() *schema.Resource {
+	return &schema.Resource{
+		Description: "An upstream DNS server Unbound forwards queries to, either plain or over TLS.",
+
+		CreateContext: unboundForwardItem.create,
+		ReadContext:   unboundForwardItem.read,
+		UpdateContext: unboundForwardItem.update,
+		DeleteContext: unboundForwardItem.delete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Description: "Enable the forwarder",
+				Optional:    true,
+				Default:     true,
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Description:  "Forward over TLS with dot or plain with forward",
+				Optional:     true,
+				Default:      "dot",
+				ValidateFunc: validation.StringInSlice([]string{"dot", "forward"}, false),
+			},
+			"domain": {
+				Type:        schema.TypeString,
+				Description: "Only forward queries for this domain, empty forwards all queries",
+				Optional:    true,
+			},
+			"server": {
+				Type:         schema.TypeString,
+				Description:  "Address of the upstream DNS server",
+				Required:     true,
+				ValidateFunc: validation.IsIPAddress,
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Description:  "Port of the upstream DNS server, empty uses 853 for dot and 53 for forward",
+				Optional:     true,
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"verify": {
+				Type:        schema.TypeString,
+				Description: "Host name the TLS certificate of the server is verified against",
+				Optional:    true,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "Description of the forwarder",
+				Optional:    true,
+			},
+		},
+	}
+}
@@ -0,0 +1,81 @@
+package opnsense
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testUnboundForwardResource(name string) string {
+	return fmt.Sprintf(`
+resource "opnsense_unbound_forward" "%s" {
+  type        = "dot"
+  server      = "9.9.9.9"
+  verify      = "dns.quad9.net"
+  description = "%s"
+}
+
+resource "opnsense_unbound_acl" "%s" {
+  name        = "%s"
+  action      = "allow"
+  networks    = ["10.20.0.0/16", "10.30.0.0/16"]
+  description = "%s"
+}
+`, name, name, name, name, name)
+}
+
+func testAccUnboundForwardResourceDestroy(s *terraform.State) error {
+	items := map[string]*mvcItem{
+		"opnsense_unbound_forward": unboundForwardItem,
+		"opnsense_unbound_acl":     unboundACLItem,
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		item, ok := items[rs.Type]
+		if !ok {
+			continue
+		}
+
+		err := testAccCheckMvcItemDestroyed(item, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestUnboundForward_acl(t *testing.T) {
+	rName := fmt.Sprintf("a%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccUnboundForwardResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnboundForwardResource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						fmt.Sprintf("opnsense_unbound_forward.%s", rName),
+						"verify",
+						"dns.quad9.net",
+					),
+					resource.TestCheckResourceAttr(
+						fmt.Sprintf("opnsense_unbound_acl.%s", rName),
+						"networks.#",
+						"2",
+					),
+				),
+			},
+			{
+				ResourceName:      fmt.Sprintf("opnsense_unbound_acl.%s", rName),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,388 @@
+package opnsense
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+type unboundHostOverride struct {
+	Enabled     mvcBool   `json:"enabled"`
+	Hostname    string    `json:"hostname"`
+	Domain      string    `json:"domain"`
+	RR          mvcOption `json:"rr"`
+	MXPriority  mvcNumber `json:"mxprio"`
+	MX          string    `json:"mx"`
+	Server      string    `json:"server"`
+	Description string    `json:"description"`
+}
+
+type unboundHostAlias struct {
+	Enabled     mvcBool   `json:"enabled"`
+	Host        mvcOption `json:"host"`
+	Hostname    string    `json:"hostname"`
+	Domain      string    `json:"domain"`
+	Description string    `json:"description"`
+}
+
+type unboundHostAliasRow struct {
+	UUID        string `json:"uuid"`
+	Hostname    string `json:"hostname"`
+	Domain      string `json:"domain"`
+	Description string `json:"description"`
+}
+
+var unboundHostOverrideItem = &mvcItem{
+	controller:  "unbound/settings",
+	name:        "HostOverride",
+	key:         "host",
+	reconfigure: unboundReconfigure,
+
+	newItem: func() interface{} { return &unboundHostOverride{} },
+	expand: func(d *schema.ResourceData) (interface{}, error) {
+		host := &unboundHostOverride{
+			Enabled:     mvcBool(d.Get("enabled").(bool)),
+			Hostname:    d.Get("hostname").(string),
+			Domain:      d.Get("domain").(string),
+			RR:          mvcOption(d.Get("type").(string)),
+			Description: d.Get("description").(string),
+		}
+
+		if host.RR == "MX" {
+			host.MX = d.Get("value").(string)
+			host.MXPriority = mvcNumber(d.Get("mx_priority").(int))
+		} else {
+			host.Server = d.Get("value").(string)
+		}
+
+		return host, nil
+	},
+	flatten: func(item interface{}) map[string]interface{} {
+		host := item.(*unboundHostOverride)
+
+		value := host.Server
+		if host.RR == "MX" {
+			value = host.MX
+		}
+
+		return map[string]interface{}{
+			"enabled":     bool(host.Enabled),
+			"hostname":    host.Hostname,
+			"domain":      host.Domain,
+			"type":        string(host.RR),
+			"value":       value,
+			"mx_priority": int(host.MXPriority),
+			"description": host.Description,
+		}
+	},
+}
+
+// unboundHostAliasItem is managed through the alias blocks of the host override.
+var unboundHostAliasItem = &mvcItem{
+	controller: "unbound/settings",
+	name:       "HostAlias",
+	key:        "alias",
+}
+
+func resourceUnboundHostOverride() *schema.Resource {
+	return &schema.Resource{
+		Description: "A host override in Unbound DNS. Unbound is reconfigured once for all " +
+			"Unbound changes made within 3 seconds of each other, see the README.",
+
+		CreateContext: resourceUnboundHostOverrideCreate,
+		ReadContext:   resourceUnboundHostOverrideRead,
+		UpdateContext: resourceUnboundHostOverrideUpdate,
+		DeleteContext: resourceUnboundHostOverrideDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		CustomizeDiff: resourceUnboundHostOverrideCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Description: "Enable the override",
+				Optional:    true,
+				Default:     true,
+			},
+			"hostname": {
+				Type:        schema.TypeString,
+				Description: "Host name without the domain, * for a wildcard or empty for the domain itself",
+				Optional:    true,
+			},
+			"domain": {
+				Type:        schema.TypeString,
+				Description: "Domain of the host, e.g. example.com",
+				Required:    true,
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Description:  "Record type, one of A, AAAA or MX",
+				Optional:     true,
+				Default:      "A",
+				ValidateFunc: validation.StringInSlice([]string{"A", "AAAA", "MX"}, false),
+			},
+			"value": {
+				Type:        schema.TypeString,
+				Description: "Address for A and AAAA records, mail server host name for MX records",
+				Required:    true,
+			},
+			"mx_priority": {
+				Type:         schema.TypeInt,
+				Description:  "Priority of the MX record",
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(0, 65535),
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "Description of the override",
+				Optional:    true,
+			},
+			"alias": {
+				Type:        schema.TypeSet,
+				Description: "Additional names resolving to the same value",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"hostname": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"domain": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceUnboundHostOverrideCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	recordType := d.Get("type").(string)
+	value := d.Get("value").(string)
+
+	// Unknown until apply, e.g. when referencing another resource
+	if value == "" {
+		return nil
+	}
+
+	ip := net.ParseIP(value)
+
+	switch recordType {
+	case "A":
+		if ip == nil || ip.To4() == nil {
+			return fmt.Errorf("value %q of an A record must be an IPv4 address", value)
+		}
+	case "AAAA":
+		if ip == nil || ip.To4() != nil {
+			return fmt.Errorf("value %q of an AAAA record must be an IPv6 address", value)
+		}
+	case "MX":
+		if ip != nil {
+			return fmt.Errorf("value %q of an MX record must be a host name", value)
+		}
+	}
+
+	return nil
+}
+
+func resourceUnboundHostOverrideRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	diags := unboundHostOverrideItem.read(ctx, d, meta)
+	if diags.HasError() || d.Id() == "" {
+		return diags
+	}
+
+	rows, err := unboundHostAliasList(meta.(*providerMeta), d.Id())
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch aliases of host override %s", d.Id())
+
+		return diag.FromErr(err)
+	}
+
+	aliases := make([]map[string]interface{}, len(rows))
+
+	for index, row := range rows {
+		aliases[index] = map[string]interface{}{
+			"hostname":    row.Hostname,
+			"domain":      row.Domain,
+			"description": row.Description,
+		}
+	}
+
+	err = d.Set("alias", aliases)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceUnboundHostOverrideCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMeta)
+
+	item, err := unboundHostOverrideItem.expand(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	id, err := mvcAdd(m.client, unboundHostOverrideItem.endpoint("add"), unboundHostOverrideItem.key, item)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id.String())
+
+	err = unboundHostAliasSync(m, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = unboundHostOverrideItem.apply(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceUnboundHostOverrideRead(ctx, d, meta)
+}
+
+func resourceUnboundHostOverrideUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMeta)
+
+	id, err := uuid.FromString(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	item, err := unboundHostOverrideItem.expand(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = mvcSet(m.client, unboundHostOverrideItem.endpoint("set", id.String()), unboundHostOverrideItem.key, item)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = unboundHostAliasSync(m, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = unboundHostOverrideItem.apply(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceUnboundHostOverrideRead(ctx, d, meta)
+}
+
+func resourceUnboundHostOverrideDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMeta)
+
+	// Aliases reference the host and would block its removal
+	rows, err := unboundHostAliasList(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, row := range rows {
+		err = mvcDelete(m.client, unboundHostAliasItem.endpoint("del", row.UUID))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return unboundHostOverrideItem.delete(ctx, d, meta)
+}
+
+// unboundHostAliasList fetches the aliases of the host override with the given uuid.
+func unboundHostAliasList(meta *providerMeta, host string) ([]unboundHostAliasRow, error) {
+	rows := []unboundHostAliasRow{}
+
+	api := unboundHostAliasItem.endpoint("search") + "?" + url.Values{"host": {host}}.Encode()
+
+	err := mvcSearch(meta.client, api, &rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// unboundHostAliasSync adds and removes aliases of the host override to
+// match the alias blocks, unchanged aliases are kept.
+func unboundHostAliasSync(meta *providerMeta, d *schema.ResourceData) error {
+	rows, err := unboundHostAliasList(meta, d.Id())
+	if err != nil {
+		return err
+	}
+
+	existing := map[unboundHostAliasRow]string{}
+
+	for _, row := range rows {
+		id := row.UUID
+		row.UUID = ""
+		existing[row] = id
+	}
+
+	for _, raw := range d.Get("alias").(*schema.Set).List() {
+		alias := raw.(map[string]interface{})
+
+		row := unboundHostAliasRow{
+			Hostname:    alias["hostname"].(string),
+			Domain:      alias["domain"].(string),
+			Description: alias["description"].(string),
+		}
+
+		if _, ok := existing[row]; ok {
+			delete(existing, row)
+
+			continue
+		}
+
+		item := &unboundHostAlias{
+			Enabled:     true,
+			Host:        mvcOption(d.Id()),
+			Hostname:    row.Hostname,
+			Domain:      row.Domain,
+			Description: row.Description,
+		}
+
+		_, err = mvcAdd(meta.client, unboundHostAliasItem.endpoint("add"), unboundHostAliasItem.key, item)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, id := range existing {
+		err = mvcDelete(meta.client, unboundHostAliasItem.endpoint("del", id))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// unboundReconfigure applies the Unbound configuration once for all changes
+// made within the batch window.
+func unboundReconfigure(meta *providerMeta) error {
+	log.Printf("[TRACE] Scheduling Unbound reconfigure")
+
+	return meta.unboundReconfigure.run()
+}
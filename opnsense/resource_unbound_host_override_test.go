@@ -0,0 +1,111 @@
+package opnsense
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testUnboundOverrideResource(name string, alias string) string {
+	return fmt.Sprintf(`
+resource "opnsense_unbound_host_override" "%s" {
+  hostname    = "%s"
+  domain      = "example.internal"
+  value       = "192.168.1.10"
+  description = "%s"
+
+  alias {
+    hostname = "%s"
+    domain   = "example.internal"
+  }
+}
+
+resource "opnsense_unbound_domain_override" "%s" {
+  domain      = "%s.example.internal"
+  server      = "192.168.1.53@5353"
+  description = "%s"
+}
+`, name, name, name, alias, name, name, name)
+}
+
+func testUnboundInvalidHostOverrideResource(name string) string {
+	return fmt.Sprintf(`
+resource "opnsense_unbound_host_override" "%s" {
+  hostname = "%s"
+  domain   = "example.internal"
+  type     = "AAAA"
+  value    = "192.168.1.10"
+}
+`, name, name)
+}
+
+func testAccUnboundOverrideResourceDestroy(s *terraform.State) error {
+	items := map[string]*mvcItem{
+		"opnsense_unbound_host_override":   unboundHostOverrideItem,
+		"opnsense_unbound_domain_override": unboundDomainOverrideItem,
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		item, ok := items[rs.Type]
+		if !ok {
+			continue
+		}
+
+		err := testAccCheckMvcItemDestroyed(item, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestUnboundOverride_alias(t *testing.T) {
+	rName := fmt.Sprintf("a%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccUnboundOverrideResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testUnboundInvalidHostOverrideResource(rName),
+				ExpectError: regexp.MustCompile("must be an IPv6 address"),
+			},
+			{
+				Config: testUnboundOverrideResource(rName, "www"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						fmt.Sprintf("opnsense_unbound_host_override.%s", rName),
+						"alias.#",
+						"1",
+					),
+					resource.TestCheckResourceAttr(
+						fmt.Sprintf("opnsense_unbound_domain_override.%s", rName),
+						"server",
+						"192.168.1.53@5353",
+					),
+				),
+			},
+			{
+				Config: testUnboundOverrideResource(rName, "mail"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs(
+						fmt.Sprintf("opnsense_unbound_host_override.%s", rName),
+						"alias.*",
+						map[string]string{"hostname": "mail"},
+					),
+				),
+			},
+			{
+				ResourceName:      fmt.Sprintf("opnsense_unbound_host_override.%s", rName),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -82,7 +82,7 @@ func resourceWireGuardClient() *schema.Resource {
 func resourceWireGuardClientRead(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[TRACE] Getting OPNsense client from meta")
 
-	c := meta.(*opnsense.Client)
+	c := meta.(*providerMeta).client
 
 	log.Printf("[TRACE] Converting ID to UUID")
 
@@ -174,7 +174,7 @@ func resourceWireGuardClientRead(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceWireGuardClientCreate(d *schema.ResourceData, meta interface{}) error {
-	c := meta.(*opnsense.Client)
+	c := meta.(*providerMeta).client
 
 	client := opnsense.WireGuardClientSet{}
 
@@ -189,13 +189,19 @@ func resourceWireGuardClientCreate(d *schema.ResourceData, meta interface{}) err
 	}
 
 	d.SetId(uuid.String())
+
+	err = wireGuardReconfigure(meta.(*providerMeta))
+	if err != nil {
+		return err
+	}
+
 	err = resourceWireGuardClientRead(d, meta)
 
 	return err
 }
 
 func resourceWireGuardClientUpdate(d *schema.ResourceData, meta interface{}) error {
-	c := meta.(*opnsense.Client)
+	c := meta.(*providerMeta).client
 
 	uuid, err := uuid.FromString(d.Id())
 	if err != nil {
@@ -214,6 +220,11 @@ func resourceWireGuardClientUpdate(d *schema.ResourceData, meta interface{}) err
 		return err
 	}
 
+	err = wireGuardReconfigure(meta.(*providerMeta))
+	if err != nil {
+		return err
+	}
+
 	d.SetId(uuid.String())
 	err = resourceWireGuardClientRead(d, meta)
 
@@ -221,7 +232,7 @@ func resourceWireGuardClientUpdate(d *schema.ResourceData, meta interface{}) err
 }
 
 func resourceWireGuardClientDelete(d *schema.ResourceData, meta interface{}) error {
-	c := meta.(*opnsense.Client)
+	c := meta.(*providerMeta).client
 
 	uuid, err := uuid.FromString(d.Id())
 	if err != nil {
@@ -233,6 +244,11 @@ func resourceWireGuardClientDelete(d *schema.ResourceData, meta interface{}) err
 		return err
 	}
 
+	err = wireGuardReconfigure(meta.(*providerMeta))
+	if err != nil {
+		return err
+	}
+
 	d.SetId("")
 
 	return nil
@@ -0,0 +1,114 @@
+package opnsense
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const wireGuardGeneralID = "wireguard"
+
+type wireGuardGeneral struct {
+	Enabled mvcBool `json:"enabled"`
+}
+
+func resourceWireGuardGeneral() *schema.Resource {
+	return &schema.Resource{
+		Description: "Global WireGuard settings. There is only one per OPNsense, " +
+			"destroying the resource disables the WireGuard service.",
+
+		CreateContext: resourceWireGuardGeneralUpdate,
+		ReadContext:   resourceWireGuardGeneralRead,
+		UpdateContext: resourceWireGuardGeneralUpdate,
+		DeleteContext: resourceWireGuardGeneralDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Description: "Enable the WireGuard service",
+				Required:    true,
+			},
+		},
+	}
+}
+
+func resourceWireGuardGeneralRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[TRACE] Getting OPNsense client from meta")
+
+	var diags diag.Diagnostics
+
+	c := meta.(*providerMeta).client
+
+	general := wireGuardGeneral{}
+
+	err := mvcGet(c, "wireguard/general/get", "general", &general)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch WireGuard general settings")
+
+		return diag.FromErr(err)
+	}
+
+	err = d.Set("enabled", bool(general.Enabled))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(wireGuardGeneralID)
+
+	return diags
+}
+
+func resourceWireGuardGeneralUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	general := wireGuardGeneral{
+		Enabled: mvcBool(d.Get("enabled").(bool)),
+	}
+
+	err := setWireGuardGeneral(meta.(*providerMeta), general)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceWireGuardGeneralRead(ctx, d, meta)
+}
+
+func resourceWireGuardGeneralDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	err := setWireGuardGeneral(meta.(*providerMeta), wireGuardGeneral{})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+func setWireGuardGeneral(meta *providerMeta, general wireGuardGeneral) error {
+	err := mvcSet(meta.client, "wireguard/general/set", "general", general)
+	if err != nil {
+		return err
+	}
+
+	return wireGuardReconfigure(meta)
+}
+
+// wireGuardReconfigure applies saved WireGuard configuration unless
+// disabled with the wireguard_reconfigure provider setting.
+func wireGuardReconfigure(meta *providerMeta) error {
+	if !meta.wireGuardReconfigure {
+		log.Printf("[DEBUG] Skipping WireGuard reconfigure")
+
+		return nil
+	}
+
+	log.Printf("[TRACE] Reconfiguring WireGuard service")
+
+	return mvcAction(meta.client, "wireguard/service/reconfigure")
+}
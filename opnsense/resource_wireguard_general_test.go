@@ -0,0 +1,76 @@
+package opnsense
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testWireguardGeneralResource(name string, enabled bool) string {
+	return fmt.Sprintf(`
+resource "opnsense_firmware" "%s" {
+    plugin {
+      name      = "os-wireguard"
+      installed = true
+    }
+}
+
+resource "opnsense_wireguard_general" "%s" {
+  enabled = %t
+
+  depends_on = [opnsense_firmware.%s]
+}
+`, name, name, enabled, name)
+}
+
+func testAccWireguardGeneralResourceDestroy(s *terraform.State) error {
+	c := testAccProvider.Meta().(*providerMeta).client
+
+	general := wireGuardGeneral{}
+
+	err := mvcGet(c, "wireguard/general/get", "general", &general)
+	if err != nil {
+		return err
+	}
+
+	if general.Enabled {
+		return fmt.Errorf("WireGuard is still enabled")
+	}
+
+	return nil
+}
+
+func TestWireguardGeneral_basic(t *testing.T) {
+	rName := fmt.Sprintf("a%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccWireguardGeneralResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testWireguardGeneralResource(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						fmt.Sprintf("opnsense_wireguard_general.%s", rName),
+						"enabled",
+						"true",
+					),
+				),
+			},
+			{
+				Config: testWireguardGeneralResource(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						fmt.Sprintf("opnsense_wireguard_general.%s", rName),
+						"enabled",
+						"false",
+					),
+				),
+			},
+		},
+	})
+}
@@ -99,7 +99,7 @@ func resourceWireGuardServer() *schema.Resource {
 func resourceWireGuardServerRead(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[TRACE] Getting OPNsense client from meta")
 
-	c := meta.(*opnsense.Client)
+	c := meta.(*providerMeta).client
 
 	log.Printf("[TRACE] Converting ID to UUID")
 
@@ -206,7 +206,7 @@ func resourceWireGuardServerRead(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceWireGuardServerCreate(d *schema.ResourceData, meta interface{}) error {
-	c := meta.(*opnsense.Client)
+	c := meta.(*providerMeta).client
 
 	server := opnsense.WireGuardServerSet{}
 
@@ -237,13 +237,18 @@ func resourceWireGuardServerCreate(d *schema.ResourceData, meta interface{}) err
 
 	d.SetId(uuids[0].String())
 
+	err = wireGuardReconfigure(meta.(*providerMeta))
+	if err != nil {
+		return err
+	}
+
 	err = resourceWireGuardServerRead(d, meta)
 
 	return err
 }
 
 func resourceWireGuardServerUpdate(d *schema.ResourceData, meta interface{}) error {
-	c := meta.(*opnsense.Client)
+	c := meta.(*providerMeta).client
 
 	uuid, err := uuid.FromString(d.Id())
 	if err != nil {
@@ -262,6 +267,11 @@ func resourceWireGuardServerUpdate(d *schema.ResourceData, meta interface{}) err
 		return err
 	}
 
+	err = wireGuardReconfigure(meta.(*providerMeta))
+	if err != nil {
+		return err
+	}
+
 	d.SetId(uuid.String())
 	err = resourceWireGuardServerRead(d, meta)
 
@@ -269,7 +279,7 @@ func resourceWireGuardServerUpdate(d *schema.ResourceData, meta interface{}) err
 }
 
 func resourceWireGuardServerDelete(d *schema.ResourceData, meta interface{}) error {
-	c := meta.(*opnsense.Client)
+	c := meta.(*providerMeta).client
 
 	uuid, err := uuid.FromString(d.Id())
 	if err != nil {
@@ -281,6 +291,11 @@ func resourceWireGuardServerDelete(d *schema.ResourceData, meta interface{}) err
 		return err
 	}
 
+	err = wireGuardReconfigure(meta.(*providerMeta))
+	if err != nil {
+		return err
+	}
+
 	d.SetId("")
 
 	return nil
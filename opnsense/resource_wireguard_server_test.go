@@ -7,7 +7,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	"github.com/kradalby/opnsense-go/opnsense"
 )
 
 func testWireguardServerResource(name string) string {
@@ -32,7 +31,7 @@ resource "opnsense_wireguard_server" "%s" {
 }
 
 func testAccWireguardServerResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*opnsense.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	servers, err := c.WireGuardServerList()
 	if err != nil {